@@ -0,0 +1,197 @@
+// Package budget schedules periodic API calls so that they fit within the API quota.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/clambin/tado/v2/transport"
+)
+
+// DefaultWindow is the quota window used when the QuotaSource doesn't report when the quota resets.
+const DefaultWindow = 24 * time.Hour
+
+// A QuotaSource reports the remaining API quota. transport.RateLimiter implements this interface.
+type QuotaSource interface {
+	Quota() (quota transport.Quota, ok bool)
+}
+
+var _ QuotaSource = &transport.RateLimiter{}
+
+// A Call is an API call that a Planner performs periodically.
+type Call struct {
+	// Do performs the call.
+	Do func(ctx context.Context)
+	// Name identifies the call in the Plan.
+	Name string
+	// Interval is the desired interval between two calls and must be positive: Run returns an error for calls
+	// with a zero or negative Interval and Plan ignores them. The Planner never calls Do more frequently than Interval.
+	Interval time.Duration
+}
+
+// A Planner performs periodic API calls, stretching their intervals so that they fit within the API quota.
+//
+// The Planner divides the remaining quota over the remaining time in the quota window. If the calls, at their
+// desired intervals, need more requests than the quota allows, all intervals are scaled by the same factor.
+// The plan is recalculated after every call, so it adapts as the remaining quota changes (e.g. because
+// other applications use the same account).
+type Planner struct {
+	// Quota reports the remaining quota. If nil, or if it doesn't know the quota yet, the Planner assumes
+	// that DailyQuota requests remain for the next DefaultWindow.
+	Quota QuotaSource
+	// Calls are the calls to perform.
+	Calls []Call
+	// DailyQuota is the number of requests allowed per day.
+	DailyQuota int
+	next       []time.Time
+	lock       sync.Mutex
+}
+
+// NewPlanner returns a Planner that performs the provided calls within a daily quota of dailyQuota requests.
+func NewPlanner(dailyQuota int, quota QuotaSource, calls ...Call) *Planner {
+	return &Planner{
+		DailyQuota: dailyQuota,
+		Quota:      quota,
+		Calls:      calls,
+	}
+}
+
+// A Plan is the Planner's schedule for the remainder of the quota window.
+type Plan struct {
+	// Reset is the end of the current quota window.
+	Reset time.Time
+	// Schedules contains the planned interval of each Call, in the order of the Planner's Calls.
+	Schedules []Schedule
+	// Remaining is the number of requests left in the current quota window.
+	Remaining int
+	// Required is the number of requests the calls need, at their desired intervals, until the quota resets.
+	Required int
+	// Scale is the factor by which the desired intervals are stretched to fit the remaining quota.
+	Scale float64
+}
+
+// A Schedule is the planned interval of a Call.
+type Schedule struct {
+	// Next is the time of the next call. It is zero if the Planner is not running.
+	Next time.Time
+	// Name is the name of the Call.
+	Name string
+	// Interval is the desired interval of the Call.
+	Interval time.Duration
+	// Planned is the interval that fits the remaining quota.
+	Planned time.Duration
+}
+
+// Plan returns the current plan.
+func (p *Planner) Plan() Plan {
+	return p.plan(time.Now())
+}
+
+func (p *Planner) plan(now time.Time) Plan {
+	plan := Plan{
+		Remaining: p.DailyQuota,
+		Reset:     now.Add(DefaultWindow),
+		Scale:     1,
+	}
+	if p.Quota != nil {
+		if quota, ok := p.Quota.Quota(); ok {
+			plan.Remaining = quota.Remaining
+			if quota.Reset.After(now) {
+				plan.Reset = quota.Reset
+			}
+		}
+	}
+
+	window := plan.Reset.Sub(now)
+	var required float64
+	for _, call := range p.Calls {
+		if call.Interval > 0 {
+			required += window.Seconds() / call.Interval.Seconds()
+		}
+	}
+	plan.Required = int(math.Ceil(required))
+	if plan.Required > plan.Remaining {
+		plan.Scale = math.Inf(1)
+		if plan.Remaining > 0 {
+			plan.Scale = required / float64(plan.Remaining)
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	plan.Schedules = make([]Schedule, len(p.Calls))
+	for i, call := range p.Calls {
+		plan.Schedules[i] = Schedule{
+			Name:     call.Name,
+			Interval: call.Interval,
+		}
+		if call.Interval <= 0 {
+			continue
+		}
+		// if no quota is left, wait for the quota to reset
+		plan.Schedules[i].Planned = window
+		if scaled := float64(call.Interval) * plan.Scale; scaled < float64(window) {
+			plan.Schedules[i].Planned = time.Duration(scaled)
+		}
+		if i < len(p.next) {
+			plan.Schedules[i].Next = p.next[i]
+		}
+	}
+	return plan
+}
+
+// Run performs the calls according to the plan, until the context is canceled. All calls are performed once on start-up.
+// Run returns an error if a Call's Interval isn't positive.
+func (p *Planner) Run(ctx context.Context) error {
+	for _, call := range p.Calls {
+		if call.Interval <= 0 {
+			return fmt.Errorf("call %q: invalid interval %v", call.Name, call.Interval)
+		}
+	}
+
+	p.lock.Lock()
+	p.next = make([]time.Time, len(p.Calls))
+	now := time.Now()
+	for i := range p.next {
+		p.next[i] = now
+	}
+	p.lock.Unlock()
+
+	if len(p.Calls) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	for {
+		i, next := p.earliest()
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		p.Calls[i].Do(ctx)
+		plan := p.Plan()
+		p.lock.Lock()
+		p.next[i] = time.Now().Add(plan.Schedules[i].Planned)
+		p.lock.Unlock()
+	}
+}
+
+// earliest returns the call that is due first.
+func (p *Planner) earliest() (int, time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var earliest int
+	for i := range p.next {
+		if p.next[i].Before(p.next[earliest]) {
+			earliest = i
+		}
+	}
+	return earliest, p.next[earliest]
+}
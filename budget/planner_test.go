@@ -0,0 +1,128 @@
+package budget
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2/transport"
+)
+
+func TestPlanner_Plan(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	calls := []Call{
+		{Name: "zoneStates", Interval: time.Minute},
+		{Name: "weather", Interval: time.Hour},
+	}
+	tests := []struct {
+		name      string
+		quota     QuotaSource
+		wantScale float64
+		want      []time.Duration
+	}{
+		{
+			name:      "no quota source: daily quota fits",
+			wantScale: 1,
+			want:      []time.Duration{time.Minute, time.Hour},
+		},
+		{
+			name:      "unknown quota: daily quota fits",
+			quota:     fakeQuota{},
+			wantScale: 1,
+			want:      []time.Duration{time.Minute, time.Hour},
+		},
+		{
+			name:      "quota fits",
+			quota:     fakeQuota{ok: true, quota: transport.Quota{Remaining: 61, Reset: now.Add(time.Hour)}},
+			wantScale: 1,
+			want:      []time.Duration{time.Minute, time.Hour},
+		},
+		{
+			name:      "quota too small",
+			quota:     fakeQuota{ok: true, quota: transport.Quota{Remaining: 122, Reset: now.Add(4 * time.Hour)}},
+			wantScale: 2,
+			want:      []time.Duration{2 * time.Minute, 2 * time.Hour},
+		},
+		{
+			name:      "quota exhausted",
+			quota:     fakeQuota{ok: true, quota: transport.Quota{Remaining: 0, Reset: now.Add(time.Hour)}},
+			wantScale: math.Inf(1),
+			want:      []time.Duration{time.Hour, time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlanner(24*61, tt.quota, calls...)
+			plan := p.plan(now)
+			if plan.Scale != tt.wantScale {
+				t.Errorf("got scale %v, want %v", plan.Scale, tt.wantScale)
+			}
+			for i, schedule := range plan.Schedules {
+				if schedule.Name != calls[i].Name || schedule.Interval != calls[i].Interval {
+					t.Errorf("unexpected schedule: %+v", schedule)
+				}
+				if schedule.Planned != tt.want[i] {
+					t.Errorf("%s: got %v, want %v", schedule.Name, schedule.Planned, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlanner_Run(t *testing.T) {
+	var fast, slow atomic.Int32
+	p := NewPlanner(100_000_000, nil,
+		Call{Name: "fast", Interval: 10 * time.Millisecond, Do: func(context.Context) { fast.Add(1) }},
+		Call{Name: "slow", Interval: time.Hour, Do: func(context.Context) { slow.Add(1) }},
+	)
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	defer cancel()
+	if err := p.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := fast.Load(); got < 5 {
+		t.Errorf("got %d fast calls, want at least 5", got)
+	}
+	if got := slow.Load(); got != 1 {
+		t.Errorf("got %d slow calls, want 1", got)
+	}
+	plan := p.Plan()
+	if next := plan.Schedules[1].Next; time.Until(next) < 59*time.Minute {
+		t.Errorf("unexpected next call: %v", next)
+	}
+}
+
+func TestPlanner_InvalidInterval(t *testing.T) {
+	p := NewPlanner(24*61, nil,
+		Call{Name: "zoneStates", Interval: time.Minute, Do: func(context.Context) {}},
+		Call{Name: "invalid", Do: func(context.Context) {}},
+	)
+
+	// Plan ignores the invalid call
+	plan := p.plan(time.Now())
+	if plan.Scale != 1 || plan.Required != 24*60 {
+		t.Errorf("got scale %v, required %d, want 1, %d", plan.Scale, plan.Required, 24*60)
+	}
+	if plan.Schedules[0].Planned != time.Minute || plan.Schedules[1].Planned != 0 {
+		t.Errorf("unexpected schedules: %+v", plan.Schedules)
+	}
+
+	// Run refuses to start
+	if err := p.Run(t.Context()); err == nil {
+		t.Error("Run should fail")
+	}
+}
+
+var _ QuotaSource = fakeQuota{}
+
+type fakeQuota struct {
+	quota transport.Quota
+	ok    bool
+}
+
+func (f fakeQuota) Quota() (transport.Quota, bool) {
+	return f.quota, f.ok
+}
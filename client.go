@@ -2,7 +2,9 @@ package tado
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 )
 
 //go:generate go tool oapi-codegen -config config.yaml https://raw.githubusercontent.com/kritsel/tado-openapispec-v2/refs/tags/v2.2025.02.03.0/tado-openapispec-v2.yaml
+//go:generate go run ./internal/cmd/generate -input client.gen.go -operations operations.gen.go -client tools/client.gen.go
 
 const (
 	ServerURL = "https://my.tado.com/api/v2"
@@ -36,37 +39,165 @@ var Config = oauth2.Config{
 //
 // Since this flow requires manual action, NewOAuth2Client returns an oauth2-enabled http.Client that works as follows:
 //   - On first start-up, the client performs the device code authentication flow to get a first token.
-//   - It calls deviceAuthCallback with the oauth2.DeviceAuthResponse, which contains the verification link (VerificationURIComplete).
+//   - It calls the device auth callback (see [WithDeviceAuthCallback]) with the oauth2.DeviceAuthResponse, which contains the verification link (VerificationURIComplete).
 //   - The application should display/log this link, asking the user to verify the login request.
-//   - Once the client receives a token, it stores this in tokenStorePath. For security, the token is encrypted with the tokenStorePassphrase.
-//   - Every time the token is renewed (10 min), the stored token is written to disk.
+//   - Once the client receives a token, it saves it in the configured token store (see [WithTokenStore]).
+//   - Every time the token is renewed (10 min), the new token is saved in the token store.
 //
-// When the application restarts, it reuses the stored token if the token is still valid. Otherwise, a new device code authentication flow is performed,
-// and the user will need to log in again.
+// When the application restarts, it reuses the stored token if the token is still valid. If the store has no token, or the token has expired,
+// a new device code authentication flow is performed, and the user will need to log in again. If the stored token can't be read
+// (e.g. the passphrase of an encrypted token store is wrong, see oauth2store.ErrBadPassphrase), NewOAuth2Client returns an error,
+// rather than overwriting the stored token.
+//
+// If the refresh token is rejected while the application is running, API calls fail with ErrReauthenticationRequired,
+// unless a ReauthHandler is configured (see [WithReauthHandler]).
+//
+// A token store is required. All other behaviour can be customized using [OAuth2ClientOption] options.
 //
 // [here]: https://github.com/wmalgadey/PyTado/issues/155
-func NewOAuth2Client(ctx context.Context, tokenStorePath string, tokenStorePassphrase string, deviceAuthCallback func(response *oauth2.DeviceAuthResponse)) (client *http.Client, err error) {
-	// store to save our token
-	store := oauth2store.NewEncryptedFileTokenStore(tokenStorePath, tokenStorePassphrase)
-	var token *oauth2.Token
+func NewOAuth2Client(ctx context.Context, opts ...OAuth2ClientOption) (client *http.Client, err error) {
+	options := oauth2ClientOptions{
+		config:      Config,
+		maxTokenAge: maxTokenAge,
+		deviceAuthCallback: func(response *oauth2.DeviceAuthResponse) {
+			slog.Info("confirm login request", "url", response.VerificationURIComplete)
+		},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.store == nil {
+		return nil, errors.New("no token store configured")
+	}
+	// the oauth2 package uses the http.Client stored in the context for all calls to the token endpoint
+	if options.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, options.httpClient)
+	}
+
+	pts := oauth2store.TokenSource{
+		TokenStore: *options.store,
+		NewTokenSource: func(token *oauth2.Token) oauth2.TokenSource {
+			return newReauthTokenSource(ctx, &options.config, token, options.reauthHandler)
+		},
+		Observers: options.observers,
+	}
+
 	// Tado refresh token is valid for 30 days
-	if time.Since(store.LastSaved()) < maxTokenAge {
-		token, _ = store.Load()
+	metadata, err := options.store.Metadata()
+	switch {
+	case errors.Is(err, oauth2store.ErrNoToken):
+	case err != nil:
+		// don't replace a token we can't read (e.g. wrong passphrase) with a new one
+		return nil, fmt.Errorf("token store: %w", err)
+	}
+	var token *oauth2.Token
+	if err == nil && time.Since(metadata.RefreshTokenIssuedAt) < options.maxTokenAge {
+		if token, err = pts.Load(); err != nil {
+			return nil, fmt.Errorf("token store: %w", err)
+		}
+		// the token is already stored: don't report it as refreshed, nor save it again
+		if err = pts.SetToken(token); err != nil {
+			return nil, err
+		}
 	}
 	// if our store doesn't have a valid token, ask the user to log in
 	if token == nil {
-		var devAuthResponse *oauth2.DeviceAuthResponse
-		if devAuthResponse, err = Config.DeviceAuth(ctx); err != nil {
-			return nil, fmt.Errorf("DevAuth: %w", err)
+		authenticator := options.authenticator
+		if authenticator == nil {
+			authenticator = NewDeviceAuthenticator(options.config, options.deviceAuthCallback)
 		}
-		deviceAuthCallback(devAuthResponse)
-		if token, err = Config.DeviceAccessToken(ctx, devAuthResponse); err != nil {
-			return nil, fmt.Errorf("DeviceAccessToken: %w", err)
+		if token, err = authenticator.Token(ctx); err != nil {
+			return nil, err
 		}
+		pts.TokenSource = pts.NewTokenSource(token)
 	}
-	pts := oauth2store.TokenSource{
-		TokenSource: Config.TokenSource(ctx, token),
-		TokenStore:  store,
-	}
+	// if the token store reports changes (e.g. another process refreshed the token), switch to the new token
+	go func() { _ = pts.Watch(ctx) }()
 	return oauth2.NewClient(ctx, &pts), nil
 }
+
+// An OAuth2ClientOption configures the http.Client created by NewOAuth2Client.
+type OAuth2ClientOption func(*oauth2ClientOptions)
+
+type oauth2ClientOptions struct {
+	store              *oauth2store.TokenStore
+	config             oauth2.Config
+	httpClient         *http.Client
+	maxTokenAge        time.Duration
+	deviceAuthCallback func(response *oauth2.DeviceAuthResponse)
+	authenticator      *DeviceAuthenticator
+	reauthHandler      ReauthHandler
+	observers          []oauth2store.Observer
+}
+
+// WithTokenStore sets the TokenStore used to persist the token.
+func WithTokenStore(store oauth2store.TokenStore) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.store = &store
+	}
+}
+
+// WithStorer persists the token in a custom Storer.
+func WithStorer(storer oauth2store.Storer) OAuth2ClientOption {
+	return WithTokenStore(oauth2store.TokenStore{Storer: storer})
+}
+
+// WithEncryptedFileTokenStore stores the token in tokenStorePath. For security, the token is encrypted with the tokenStorePassphrase.
+func WithEncryptedFileTokenStore(tokenStorePath string, tokenStorePassphrase string) OAuth2ClientOption {
+	return WithTokenStore(oauth2store.NewEncryptedFileTokenStore(tokenStorePath, tokenStorePassphrase))
+}
+
+// WithOAuth2Config overrides the oauth2 configuration. By default, NewOAuth2Client uses [Config].
+func WithOAuth2Config(config oauth2.Config) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.config = config
+	}
+}
+
+// WithOAuth2Endpoint overrides the endpoints of the oauth2 configuration, e.g. to use a local authentication server in tests.
+func WithOAuth2Endpoint(endpoint oauth2.Endpoint) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.config.Endpoint = endpoint
+	}
+}
+
+// WithBaseHTTPClient sets the http.Client used to call the oauth2 endpoints. Its Transport is also used as
+// the base transport of the returned http.Client.
+func WithBaseHTTPClient(httpClient *http.Client) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithMaxTokenAge sets the maximum age of a stored token's refresh token. Older tokens are discarded and the user needs to log in again.
+// By default, this is 30 days, which is the lifetime of a Tadoº refresh token.
+func WithMaxTokenAge(maxTokenAge time.Duration) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.maxTokenAge = maxTokenAge
+	}
+}
+
+// WithDeviceAuthCallback sets the function called when the user needs to confirm the login request.
+// By default, the verification link is logged with slog.
+func WithDeviceAuthCallback(deviceAuthCallback func(response *oauth2.DeviceAuthResponse)) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.deviceAuthCallback = deviceAuthCallback
+	}
+}
+
+// WithDeviceAuthenticator performs the device code authentication flow with the provided DeviceAuthenticator,
+// so the application can report the progress of the login request. The oauth2 configuration and device auth callback
+// of the DeviceAuthenticator take precedence over the ones set with WithOAuth2Config and WithDeviceAuthCallback.
+func WithDeviceAuthenticator(authenticator *DeviceAuthenticator) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithObservers sets the Observers notified of token lifecycle events (e.g. token refreshes and failures to save the token).
+// Use oauth2store.AuditLog to log these events.
+func WithObservers(observers ...oauth2store.Observer) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.observers = append(o.observers, observers...)
+	}
+}
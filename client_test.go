@@ -1,10 +1,17 @@
 package tado
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/clambin/tado/v2/oauth2store"
 	"golang.org/x/oauth2"
 )
 
@@ -13,11 +20,10 @@ func TestNewOAuth2Client(t *testing.T) {
 
 	httpClient, err := NewOAuth2Client(
 		t.Context(),
-		filepath.Join(t.TempDir(), "token.enc"),
-		"my-very-secret-passphrase",
-		func(response *oauth2.DeviceAuthResponse) {
+		WithEncryptedFileTokenStore(filepath.Join(t.TempDir(), "token.enc"), "my-very-secret-passphrase"),
+		WithDeviceAuthCallback(func(response *oauth2.DeviceAuthResponse) {
 			t.Logf("confirm login request: %+v", response.VerificationURIComplete)
-		},
+		}),
 	)
 	if err != nil {
 		t.Fatalf("NewOAuth2Client failed: %v", err)
@@ -36,3 +42,280 @@ func TestNewOAuth2Client(t *testing.T) {
 		t.Fatalf("GetMe() failed: %v", code)
 	}
 }
+
+func TestNewOAuth2Client_Options(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+
+	api := newFakeAPIServer()
+	defer api.Close()
+
+	var store fakeStorer
+	var callbacks atomic.Int32
+	var requests atomic.Int32
+	opts := []OAuth2ClientOption{
+		WithStorer(&store),
+		WithOAuth2Endpoint(auth.endpoint()),
+		WithBaseHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return http.DefaultTransport.RoundTrip(r)
+		})}),
+		WithMaxTokenAge(time.Hour),
+		WithDeviceAuthCallback(func(_ *oauth2.DeviceAuthResponse) { callbacks.Add(1) }),
+	}
+
+	// first run: device auth flow
+	httpClient, err := NewOAuth2Client(t.Context(), opts...)
+	if err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	if got := callbacks.Load(); got != 1 {
+		t.Errorf("got %d callbacks, want 1", got)
+	}
+	getMe(t, httpClient, api.URL)
+
+	// second run: token is loaded from the store
+	if httpClient, err = NewOAuth2Client(t.Context(), opts...); err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	if got := callbacks.Load(); got != 1 {
+		t.Errorf("got %d callbacks, want 1", got)
+	}
+	getMe(t, httpClient, api.URL)
+
+	// all calls went through our base http client
+	if got := requests.Load(); got < 4 {
+		t.Errorf("got %d requests through base http client, want at least 4", got)
+	}
+}
+
+func TestNewOAuth2Client_LoadedToken(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+
+	api := newFakeAPIServer()
+	defer api.Close()
+
+	var store fakeStorer
+	var refreshes atomic.Int32
+	opts := []OAuth2ClientOption{
+		WithStorer(&store),
+		WithOAuth2Endpoint(auth.endpoint()),
+		WithObservers(oauth2store.Observer{OnRefresh: func(_, _ *oauth2.Token) { refreshes.Add(1) }}),
+	}
+
+	// first run: the token from the device auth flow is reported as new
+	httpClient, err := NewOAuth2Client(t.Context(), opts...)
+	if err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	getMe(t, httpClient, api.URL)
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("got %d refreshes, want 1", got)
+	}
+
+	// second run: the loaded token isn't reported as refreshed
+	if httpClient, err = NewOAuth2Client(t.Context(), opts...); err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	getMe(t, httpClient, api.URL)
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("got %d refreshes, want 1", got)
+	}
+}
+
+func TestNewOAuth2Client_BadPassphrase(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+
+	api := newFakeAPIServer()
+	defer api.Close()
+
+	path := filepath.Join(t.TempDir(), "token.enc")
+	var callbacks atomic.Int32
+	newClient := func(passphrase string) (*http.Client, error) {
+		return NewOAuth2Client(t.Context(),
+			WithEncryptedFileTokenStore(path, passphrase),
+			WithOAuth2Endpoint(auth.endpoint()),
+			WithDeviceAuthCallback(func(_ *oauth2.DeviceAuthResponse) { callbacks.Add(1) }),
+		)
+	}
+
+	// first run: device auth flow. the token is saved when it is first used.
+	httpClient, err := newClient("my-very-secret-passphrase")
+	if err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	getMe(t, httpClient, api.URL)
+
+	// wrong passphrase: fail, rather than logging in again and overwriting the stored token
+	if _, err = newClient("wrong-passphrase"); !errors.Is(err, oauth2store.ErrBadPassphrase) {
+		t.Fatalf("got err %v, want %v", err, oauth2store.ErrBadPassphrase)
+	}
+	if got := callbacks.Load(); got != 1 {
+		t.Errorf("got %d callbacks, want 1", got)
+	}
+	// the stored token is still valid
+	if _, err = newClient("my-very-secret-passphrase"); err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	if got := callbacks.Load(); got != 1 {
+		t.Errorf("got %d callbacks, want 1", got)
+	}
+}
+
+func TestNewOAuth2Client_KeyringUnavailable(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+
+	api := newFakeAPIServer()
+	defer api.Close()
+
+	// the keyring command isn't installed and the file doesn't exist yet: the user needs to log in
+	dir := t.TempDir()
+	storer := oauth2store.FallbackStorer{
+		oauth2store.KeyringStorer{Keyring: oauth2store.Pass{Command: filepath.Join(dir, "pass")}, Service: "tado", Account: "foo"},
+		oauth2store.NewEncryptedFileStorer(filepath.Join(dir, "token.enc"), "my-very-secret-passphrase"),
+	}
+	var callbacks atomic.Int32
+	httpClient, err := NewOAuth2Client(t.Context(),
+		WithStorer(storer),
+		WithOAuth2Endpoint(auth.endpoint()),
+		WithDeviceAuthCallback(func(_ *oauth2.DeviceAuthResponse) { callbacks.Add(1) }),
+	)
+	if err != nil {
+		t.Fatalf("NewOAuth2Client failed: %v", err)
+	}
+	if got := callbacks.Load(); got != 1 {
+		t.Errorf("got %d callbacks, want 1", got)
+	}
+	getMe(t, httpClient, api.URL)
+
+	// the token was saved in the file
+	if token, err := (oauth2store.TokenStore{Storer: storer}).Load(); err != nil || token.AccessToken != "access-token" {
+		t.Errorf("Load() = (%v, %v), want access-token", token, err)
+	}
+}
+
+func TestNewOAuth2Client_NoStore(t *testing.T) {
+	if _, err := NewOAuth2Client(t.Context()); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// newFakeAPIServer returns a server that responds to GetMe calls, authenticated with the token issued by fakeAuthServer.
+func newFakeAPIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"foo"}`))
+	}))
+}
+
+func getMe(t *testing.T, httpClient *http.Client, server string) {
+	t.Helper()
+	client, err := NewClientWithResponses(server, WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	resp, err := client.GetMeWithResponse(t.Context())
+	if err != nil {
+		t.Fatalf("GetMe() failed: %v", err)
+	}
+	if code := resp.StatusCode(); code != http.StatusOK {
+		t.Fatalf("GetMe() failed: %v", code)
+	}
+}
+
+var _ oauth2store.Storer = &fakeStorer{}
+
+type fakeStorer struct {
+	token atomic.Value
+}
+
+func (f *fakeStorer) Save(bytes []byte) error {
+	f.token.Store(bytes)
+	return nil
+}
+
+func (f *fakeStorer) Load() ([]byte, error) {
+	token, ok := f.token.Load().([]byte)
+	if !ok {
+		return nil, oauth2store.ErrNoToken
+	}
+	return token, nil
+}
+
+// fakeAuthServer implements the device code flow and the token endpoint of an oauth2 server.
+type fakeAuthServer struct {
+	*httptest.Server
+	// number of polling attempts before the login request is approved
+	pending atomic.Int32
+	// deny the login request
+	deny atomic.Bool
+	// number of device codes that expire before the login request is approved
+	expire atomic.Int32
+	// reject refresh tokens
+	revoke atomic.Bool
+	codes  atomic.Int32
+}
+
+func newFakeAuthServer() *fakeAuthServer {
+	var s fakeAuthServer
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /device_authorize", func(w http.ResponseWriter, _ *http.Request) {
+		code := strconv.Itoa(int(s.codes.Add(1)))
+		writeJSON(w, http.StatusOK, map[string]any{
+			"device_code":               "device-code-" + code,
+			"user_code":                 "user-code-" + code,
+			"verification_uri":          "https://example.com/verify",
+			"verification_uri_complete": "https://example.com/verify?user_code=user-code-" + code,
+			"expires_in":                300,
+			"interval":                  1,
+		})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") == "urn:ietf:params:oauth:grant-type:device_code" {
+			switch {
+			case s.deny.Load():
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "access_denied"})
+				return
+			case s.expire.Add(-1) >= 0:
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "expired_token"})
+				return
+			case s.pending.Add(-1) >= 0:
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "authorization_pending"})
+				return
+			}
+		}
+		if r.FormValue("grant_type") == "refresh_token" && s.revoke.Load() {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token":  "access-token",
+			"refresh_token": "refresh-token",
+			"token_type":    "Bearer",
+			"expires_in":    600,
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	return &s
+}
+
+func (s *fakeAuthServer) endpoint() oauth2.Endpoint {
+	return oauth2.Endpoint{
+		DeviceAuthURL: s.URL + "/device_authorize",
+		TokenURL:      s.URL + "/token",
+		AuthStyle:     oauth2.AuthStyleInParams,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,65 @@
+package tado
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/clambin/tado/v2/oauth2store"
+)
+
+// A ClientFactory creates http.Clients for multiple Tadoº accounts, whose tokens are kept in a single MultiTokenStore.
+// Clients are created on demand and reused for subsequent calls.
+type ClientFactory struct {
+	ctx     context.Context
+	store   *oauth2store.MultiTokenStore
+	clients map[string]*factoryClient
+	options []OAuth2ClientOption
+	lock    sync.Mutex
+}
+
+type factoryClient struct {
+	client *http.Client
+	lock   sync.Mutex
+}
+
+// NewClientFactory returns a ClientFactory that stores the tokens of all accounts in store.
+// The options are applied to all clients created by the factory. Any token store option is ignored.
+func NewClientFactory(ctx context.Context, store *oauth2store.MultiTokenStore, opts ...OAuth2ClientOption) *ClientFactory {
+	return &ClientFactory{
+		ctx:     ctx,
+		store:   store,
+		clients: make(map[string]*factoryClient),
+		options: opts,
+	}
+}
+
+// Client returns the http.Client for the provided account. On the first call for an account, Client creates the client
+// with NewOAuth2Client, using the factory's options, followed by opts. If the store does not hold a valid token for the account,
+// this performs the device code authentication flow, so opts typically includes a WithDeviceAuthCallback that tells the user
+// which account to log in with.
+func (f *ClientFactory) Client(account string, opts ...OAuth2ClientOption) (*http.Client, error) {
+	f.lock.Lock()
+	c, ok := f.clients[account]
+	if !ok {
+		c = &factoryClient{}
+		f.clients[account] = c
+	}
+	f.lock.Unlock()
+
+	// creating a client may block while the user logs in: only block callers for the same account
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.client == nil {
+		options := make([]OAuth2ClientOption, 0, len(f.options)+len(opts)+1)
+		options = append(options, f.options...)
+		options = append(options, opts...)
+		options = append(options, WithTokenStore(f.store.For(account)))
+		client, err := NewOAuth2Client(f.ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
+	return c.client, nil
+}
@@ -0,0 +1,64 @@
+package tado
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestClientFactory_Client(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+	api := newFakeAPIServer()
+	defer api.Close()
+
+	store := oauth2store.MultiTokenStore{Storer: &fakeStorer{}}
+	var callbacks atomic.Int32
+	factory := NewClientFactory(t.Context(), &store,
+		WithOAuth2Endpoint(auth.endpoint()),
+		WithDeviceAuthCallback(func(_ *oauth2.DeviceAuthResponse) { callbacks.Add(1) }),
+	)
+
+	home, err := factory.Client("home")
+	if err != nil {
+		t.Fatalf("Client() failed: %v", err)
+	}
+	office, err := factory.Client("office")
+	if err != nil {
+		t.Fatalf("Client() failed: %v", err)
+	}
+	if home == office {
+		t.Error("accounts share the same client")
+	}
+	// subsequent calls return the same client
+	if client, _ := factory.Client("home"); client != home {
+		t.Error("Client() created a new client")
+	}
+	if got := callbacks.Load(); got != 2 {
+		t.Errorf("got %d logins, want 2", got)
+	}
+
+	// tokens are saved when they are first used
+	getMe(t, home, api.URL)
+	getMe(t, office, api.URL)
+
+	// the store holds the tokens of both accounts
+	accounts, err := store.Accounts()
+	if err != nil {
+		t.Fatalf("Accounts() failed: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Errorf("got accounts %v, want 2 accounts", accounts)
+	}
+
+	// a new factory reuses the stored tokens
+	factory = NewClientFactory(t.Context(), &store, WithOAuth2Endpoint(auth.endpoint()))
+	if _, err = factory.Client("home"); err != nil {
+		t.Fatalf("Client() failed: %v", err)
+	}
+	if got := callbacks.Load(); got != 2 {
+		t.Errorf("got %d logins, want 2", got)
+	}
+}
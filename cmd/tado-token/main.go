@@ -0,0 +1,107 @@
+// tado-token inspects and maintains an encrypted token store, as created by tado.NewOAuth2Client.
+//
+// Usage:
+//
+//	tado-token inspect <path>
+//	tado-token rekey <path>
+//
+// The passphrase of the token store is read from the TADO_TOKEN_PASSPHRASE environment variable.
+// rekey reads the new passphrase from TADO_TOKEN_NEW_PASSPHRASE.
+//
+// inspect never prints the access or refresh token.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clambin/tado/v2/oauth2store"
+)
+
+const usage = `usage:
+  tado-token inspect <path>
+  tado-token rekey <path>
+
+environment:
+  TADO_TOKEN_PASSPHRASE      passphrase of the token store
+  TADO_TOKEN_NEW_PASSPHRASE  new passphrase (rekey only)
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Getenv, time.Now()); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer, getenv func(string) string, now time.Time) error {
+	if len(args) != 2 {
+		return errors.New(usage)
+	}
+	passphrase := getenv("TADO_TOKEN_PASSPHRASE")
+	if passphrase == "" {
+		return errors.New("TADO_TOKEN_PASSPHRASE not set")
+	}
+	store := oauth2store.NewEncryptedFileTokenStore(args[1], passphrase)
+
+	switch args[0] {
+	case "inspect":
+		return inspect(w, store, now)
+	case "rekey":
+		newPassphrase := getenv("TADO_TOKEN_NEW_PASSPHRASE")
+		if newPassphrase == "" {
+			return errors.New("TADO_TOKEN_NEW_PASSPHRASE not set")
+		}
+		if err := store.Rekey(passphrase, newPassphrase); err != nil {
+			return fmt.Errorf("rekey: %w", err)
+		}
+		_, err := fmt.Fprintln(w, "token store re-encrypted")
+		return err
+	default:
+		return errors.New(usage)
+	}
+}
+
+func inspect(w io.Writer, store oauth2store.TokenStore, now time.Time) error {
+	token, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+	metadata, err := store.Metadata()
+	if err != nil {
+		return fmt.Errorf("metadata: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if metadata.Account != "" {
+		_, _ = fmt.Fprintf(tw, "account:\t%s\n", metadata.Account)
+	}
+	_, _ = fmt.Fprintf(tw, "saved at:\t%s\n", formatTime(metadata.SavedAt, now))
+	_, _ = fmt.Fprintf(tw, "refresh token issued at:\t%s\n", formatTime(metadata.RefreshTokenIssuedAt, now))
+	_, _ = fmt.Fprintf(tw, "token type:\t%s\n", token.Type())
+	_, _ = fmt.Fprintf(tw, "access token expiry:\t%s\n", formatTime(token.Expiry, now))
+	_, _ = fmt.Fprintf(tw, "refresh token:\t%s\n", present(token.RefreshToken != ""))
+	return tw.Flush()
+}
+
+func formatTime(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	d := t.Sub(now).Round(time.Second)
+	if d >= 0 {
+		return fmt.Sprintf("%s (in %s)", t.Format(time.RFC3339), d)
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), -d)
+}
+
+func present(ok bool) string {
+	if ok {
+		return "present"
+	}
+	return "missing"
+}
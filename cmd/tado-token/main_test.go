@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.enc")
+	now := time.Now()
+	store := oauth2store.NewEncryptedFileTokenStore(path, "passphrase")
+	if err := store.Save(&oauth2.Token{
+		AccessToken:  "secret-access-token",
+		RefreshToken: "secret-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       now.Add(10 * time.Minute),
+	}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	env := map[string]string{"TADO_TOKEN_PASSPHRASE": "passphrase", "TADO_TOKEN_NEW_PASSPHRASE": "new-passphrase"}
+	getenv := func(key string) string { return env[key] }
+
+	var out bytes.Buffer
+	if err := run([]string{"inspect", path}, &out, getenv, now); err != nil {
+		t.Fatalf("inspect failed: %v", err)
+	}
+	for _, want := range []string{"saved at:", "access token expiry:", "(in 10m0s)", "refresh token:", "present", "Bearer"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "secret") {
+		t.Errorf("output contains secrets:\n%s", out.String())
+	}
+
+	if err := run([]string{"rekey", path}, &out, getenv, now); err != nil {
+		t.Fatalf("rekey failed: %v", err)
+	}
+	if err := run([]string{"inspect", path}, &out, getenv, now); !errors.Is(err, oauth2store.ErrBadPassphrase) {
+		t.Errorf("inspect with old passphrase: error = %v, want %v", err, oauth2store.ErrBadPassphrase)
+	}
+
+	for _, args := range [][]string{nil, {"foo", path}} {
+		if err := run(args, &out, getenv, now); err == nil {
+			t.Errorf("run(%v) should fail", args)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DeviceAuthState is the state of a device code authentication flow.
+type DeviceAuthState int
+
+const (
+	// DeviceAuthIdle indicates that no device code authentication flow has been started.
+	DeviceAuthIdle DeviceAuthState = iota
+	// DeviceAuthPending indicates that the flow is waiting for the user to confirm the login request.
+	DeviceAuthPending
+	// DeviceAuthApproved indicates that the user approved the login request.
+	DeviceAuthApproved
+	// DeviceAuthDenied indicates that the user denied the login request.
+	DeviceAuthDenied
+	// DeviceAuthExpired indicates that the device code expired before the user confirmed the login request.
+	// DeviceAuthenticator automatically starts a new flow.
+	DeviceAuthExpired
+	// DeviceAuthFailed indicates that the flow failed. DeviceAuthStatus.Err contains the reason.
+	DeviceAuthFailed
+)
+
+func (s DeviceAuthState) String() string {
+	switch s {
+	case DeviceAuthIdle:
+		return "idle"
+	case DeviceAuthPending:
+		return "pending"
+	case DeviceAuthApproved:
+		return "approved"
+	case DeviceAuthDenied:
+		return "denied"
+	case DeviceAuthExpired:
+		return "expired"
+	case DeviceAuthFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
+// DeviceAuthStatus reports the status of a device code authentication flow.
+type DeviceAuthStatus struct {
+	Expiry                  time.Time
+	Err                     error
+	VerificationURI         string
+	VerificationURIComplete string
+	UserCode                string
+	State                   DeviceAuthState
+	// Attempts is the number of times the token endpoint has been polled for the current device code.
+	Attempts int
+}
+
+// ErrDeviceAuthDenied is returned when the user denies the login request.
+var ErrDeviceAuthDenied = errors.New("login request denied")
+
+// A DeviceAuthenticator performs the oauth2 device code authentication flow and reports its progress.
+//
+// Unlike oauth2.Config's DeviceAccessToken, the state of the flow (verification link, user code, expiry, number of polling attempts)
+// can be queried while the flow is running, either with Status, or by reading from Updates. When the device code expires
+// before the user confirms the login request, DeviceAuthenticator requests a new device code and continues polling.
+//
+// This allows applications to run the flow in the background (e.g. by passing the DeviceAuthenticator to NewOAuth2Client
+// using [WithDeviceAuthenticator]) and show its progress to the user, e.g. in a web UI.
+type DeviceAuthenticator struct {
+	config   oauth2.Config
+	callback func(*oauth2.DeviceAuthResponse)
+	updates  chan DeviceAuthStatus
+	status   DeviceAuthStatus
+	lock     sync.RWMutex
+}
+
+// NewDeviceAuthenticator returns a DeviceAuthenticator for the provided oauth2 configuration.
+// If callback is not nil, it is called every time a new device code is issued.
+func NewDeviceAuthenticator(config oauth2.Config, callback func(*oauth2.DeviceAuthResponse)) *DeviceAuthenticator {
+	return &DeviceAuthenticator{
+		config:   config,
+		callback: callback,
+		updates:  make(chan DeviceAuthStatus, 1),
+	}
+}
+
+// Status returns the current status of the device code authentication flow.
+func (d *DeviceAuthenticator) Status() DeviceAuthStatus {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.status
+}
+
+// Updates returns a channel that receives the status every time it changes. If the receiver falls behind,
+// older updates are dropped: the channel always holds the most recent status.
+func (d *DeviceAuthenticator) Updates() <-chan DeviceAuthStatus {
+	return d.updates
+}
+
+// Token performs the device code authentication flow and returns the resulting token.
+// Token blocks until the user approves or denies the login request, or the context is canceled.
+// If the device code expires, Token starts a new flow.
+func (d *DeviceAuthenticator) Token(ctx context.Context) (*oauth2.Token, error) {
+	// count the number of polling attempts by intercepting the calls to the token endpoint
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, d.pollingClient(ctx))
+
+	for {
+		response, err := d.config.DeviceAuth(ctx)
+		if err != nil {
+			d.update(func(s *DeviceAuthStatus) { s.State = DeviceAuthFailed; s.Err = err })
+			return nil, fmt.Errorf("DevAuth: %w", err)
+		}
+		d.update(func(s *DeviceAuthStatus) {
+			*s = DeviceAuthStatus{
+				State:                   DeviceAuthPending,
+				VerificationURI:         response.VerificationURI,
+				VerificationURIComplete: response.VerificationURIComplete,
+				UserCode:                response.UserCode,
+				Expiry:                  response.Expiry,
+			}
+		})
+		if d.callback != nil {
+			d.callback(response)
+		}
+
+		token, err := d.config.DeviceAccessToken(ctx, response)
+		switch {
+		case err == nil:
+			d.update(func(s *DeviceAuthStatus) { s.State = DeviceAuthApproved })
+			return token, nil
+		case retrieveErrorCode(err) == "access_denied":
+			d.update(func(s *DeviceAuthStatus) { s.State = DeviceAuthDenied; s.Err = ErrDeviceAuthDenied })
+			return nil, fmt.Errorf("DeviceAccessToken: %w", ErrDeviceAuthDenied)
+		case ctx.Err() == nil && (retrieveErrorCode(err) == "expired_token" || errors.Is(err, context.DeadlineExceeded)):
+			// the device code expired: start a new flow
+			d.update(func(s *DeviceAuthStatus) { s.State = DeviceAuthExpired })
+		default:
+			d.update(func(s *DeviceAuthStatus) { s.State = DeviceAuthFailed; s.Err = err })
+			return nil, fmt.Errorf("DeviceAccessToken: %w", err)
+		}
+	}
+}
+
+func (d *DeviceAuthenticator) update(f func(*DeviceAuthStatus)) {
+	d.lock.Lock()
+	f(&d.status)
+	status := d.status
+	d.lock.Unlock()
+
+	// drop the previous update if it hasn't been read yet
+	select {
+	case <-d.updates:
+	default:
+	}
+	select {
+	case d.updates <- status:
+	default:
+	}
+}
+
+func (d *DeviceAuthenticator) pollingClient(ctx context.Context) *http.Client {
+	var client http.Client
+	if base, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && base != nil {
+		client = *base
+	}
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == d.config.Endpoint.TokenURL {
+			d.update(func(s *DeviceAuthStatus) { s.Attempts++ })
+		}
+		return next.RoundTrip(req)
+	})
+	return &client
+}
+
+func retrieveErrorCode(err error) string {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return retrieveErr.ErrorCode
+	}
+	return ""
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
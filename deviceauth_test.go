@@ -0,0 +1,112 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceAuthenticator_Token(t *testing.T) {
+	tests := []struct {
+		name      string
+		pending   int32
+		expire    int32
+		deny      bool
+		wantErr   error
+		wantState DeviceAuthState
+		wantCodes int32
+	}{
+		{name: "approved", pending: 1, wantState: DeviceAuthApproved, wantCodes: 1},
+		{name: "denied", deny: true, wantErr: ErrDeviceAuthDenied, wantState: DeviceAuthDenied, wantCodes: 1},
+		{name: "expired", expire: 1, wantState: DeviceAuthApproved, wantCodes: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newFakeAuthServer()
+			defer auth.Close()
+			auth.pending.Store(tt.pending)
+			auth.expire.Store(tt.expire)
+			auth.deny.Store(tt.deny)
+
+			cfg := Config
+			cfg.Endpoint = auth.endpoint()
+			var callbacks []string
+			authenticator := NewDeviceAuthenticator(cfg, func(response *oauth2.DeviceAuthResponse) {
+				callbacks = append(callbacks, response.UserCode)
+			})
+
+			token, err := authenticator.Token(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Token() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && token.AccessToken != "access-token" {
+				t.Errorf("got %q, want access-token", token.AccessToken)
+			}
+
+			status := authenticator.Status()
+			if status.State != tt.wantState {
+				t.Errorf("got state %s, want %s", status.State, tt.wantState)
+			}
+			if want := "user-code-" + string(rune('0'+tt.wantCodes)); status.UserCode != want {
+				t.Errorf("got user code %q, want %q", status.UserCode, want)
+			}
+			if want := int(tt.pending) + 1; status.Attempts != want {
+				t.Errorf("got %d attempts, want %d", status.Attempts, want)
+			}
+			if got := len(callbacks); got != int(tt.wantCodes) {
+				t.Errorf("got %d callbacks, want %d", got, tt.wantCodes)
+			}
+
+			// Updates holds the most recent status
+			if update := <-authenticator.Updates(); update.State != tt.wantState {
+				t.Errorf("got update %s, want %s", update.State, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestDeviceAuthenticator_Canceled(t *testing.T) {
+	auth := newFakeAuthServer()
+	defer auth.Close()
+	auth.pending.Store(100)
+
+	cfg := Config
+	cfg.Endpoint = auth.endpoint()
+	authenticator := NewDeviceAuthenticator(cfg, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		for update := range authenticator.Updates() {
+			if update.State == DeviceAuthPending && update.Attempts > 0 {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	if _, err := authenticator.Token(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Token() error = %v, want %v", err, context.Canceled)
+	}
+	if state := authenticator.Status().State; state != DeviceAuthFailed {
+		t.Errorf("got state %s, want %s", state, DeviceAuthFailed)
+	}
+}
+
+func TestDeviceAuthState_String(t *testing.T) {
+	for state, want := range map[DeviceAuthState]string{
+		DeviceAuthIdle:      "idle",
+		DeviceAuthPending:   "pending",
+		DeviceAuthApproved:  "approved",
+		DeviceAuthDenied:    "denied",
+		DeviceAuthExpired:   "expired",
+		DeviceAuthFailed:    "failed",
+		DeviceAuthState(-1): "unknown (-1)",
+	} {
+		if got := state.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
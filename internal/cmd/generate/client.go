@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// method describes a method of tools.Client.
+type method struct {
+	Name string
+	// Doc is the first line of the documentation of the generated method
+	Doc string
+	// Params is the parameter list, excluding the context and request editors
+	Params []param
+	// Payload is the type of the response's JSON200 field. If empty, the method only returns an error.
+	Payload string
+}
+
+type param struct {
+	Name string
+	Type string
+}
+
+// generateClient returns the methods of tools.Client for the generated client.
+func generateClient(src []byte) ([]byte, error) {
+	methods, err := parseClient(src)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = clientTemplate.Execute(&buf, methods); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// parseClient returns a method for every XxxWithResponse method of ClientWithResponsesInterface.
+// XxxWithBodyWithResponse methods are skipped: tools.Client only supports JSON request bodies.
+func parseClient(src []byte) ([]method, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var iface *ast.InterfaceType
+	payloads := make(map[string]ast.Expr)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			switch t := typeSpec.Type.(type) {
+			case *ast.InterfaceType:
+				if typeSpec.Name.Name == "ClientWithResponsesInterface" {
+					iface = t
+				}
+			case *ast.StructType:
+				for _, field := range t.Fields.List {
+					if len(field.Names) == 1 && field.Names[0].Name == "JSON200" {
+						payloads[typeSpec.Name.Name] = field.Type
+					}
+				}
+			}
+		}
+	}
+	if iface == nil {
+		return nil, fmt.Errorf("ClientWithResponsesInterface not found")
+	}
+
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		name, ok := strings.CutSuffix(field.Names[0].Name, "WithResponse")
+		if !ok || strings.HasSuffix(name, "WithBody") {
+			continue
+		}
+		m := method{Name: name}
+		if field.Doc != nil {
+			doc := strings.TrimSpace(strings.SplitN(field.Doc.Text(), "\n", 2)[0])
+			m.Doc = strings.Replace(doc, field.Names[0].Name, name, 1)
+		}
+		// skip the context and the request editors
+		params := fn.Params.List
+		if len(params) < 2 {
+			return nil, fmt.Errorf("%s: unexpected parameters", field.Names[0].Name)
+		}
+		for _, p := range params[1 : len(params)-1] {
+			typ, err := qualify(p.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Names[0].Name, err)
+			}
+			for _, n := range p.Names {
+				m.Params = append(m.Params, param{Name: n.Name, Type: typ})
+			}
+		}
+		if fn.Results == nil || len(fn.Results.List) != 2 {
+			return nil, fmt.Errorf("%s: unexpected results", field.Names[0].Name)
+		}
+		resp, ok := fn.Results.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			return nil, fmt.Errorf("%s: unexpected response type", field.Names[0].Name)
+		}
+		if payload, ok := payloads[resp.X.(*ast.Ident).Name]; ok {
+			star, ok := payload.(*ast.StarExpr)
+			if !ok {
+				return nil, fmt.Errorf("%s: unexpected payload type", field.Names[0].Name)
+			}
+			if m.Payload, err = qualify(star.X); err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Names[0].Name, err)
+			}
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// qualify returns the type expression, with all types declared in the generated client prefixed with the tado package.
+func qualify(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return "tado." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.StarExpr:
+		typ, err := qualify(t.X)
+		return "*" + typ, err
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", fmt.Errorf("unsupported array type")
+		}
+		typ, err := qualify(t.Elt)
+		return "[]" + typ, err
+	case *ast.MapType:
+		key, err := qualify(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := qualify(t.Value)
+		return "map[" + key + "]" + value, err
+	default:
+		return "", fmt.Errorf("unsupported type %T", expr)
+	}
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by internal/cmd/generate. DO NOT EDIT.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/clambin/tado/v2"
+)
+{{ range . }}
+{{- if .Doc }}
+// {{ .Doc }}
+{{- end }}
+func (c *Client) {{ .Name }}(ctx context.Context, {{ range .Params }}{{ .Name }} {{ .Type }}, {{ end }}reqEditors ...tado.RequestEditorFn) {{ if .Payload }}({{ .Payload }}, error){{ else }}error{{ end }} {
+	return {{ if .Payload }}Result{{ else }}Check{{ end }}(c.client.{{ .Name }}WithResponse(ctx, {{ range .Params }}{{ .Name }}, {{ end }}reqEditors...))
+}
+{{ end }}`))
@@ -0,0 +1,185 @@
+// Command generate generates code from the client generated by oapi-codegen (client.gen.go).
+//
+// It generates:
+//   - operations.gen.go, which describes all operations of the Tadoº API, so that requests can be
+//     mapped to the generated operation that created them.
+//   - tools/client.gen.go, which implements the methods of tools.Client.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	input      = flag.String("input", "client.gen.go", "client generated by oapi-codegen")
+	operations = flag.String("operations", "operations.gen.go", "output file for the operations table")
+	client     = flag.String("client", "tools/client.gen.go", "output file for the methods of tools.Client")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(*input, *operations, *client); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(input, operationsOutput, clientOutput string) error {
+	src, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+	out, err := generateOperations(src)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(operationsOutput, out, 0644); err != nil {
+		return err
+	}
+	if out, err = generateClient(src); err != nil {
+		return err
+	}
+	return os.WriteFile(clientOutput, out, 0644)
+}
+
+// generateOperations returns the operations table for the generated client.
+func generateOperations(src []byte) ([]byte, error) {
+	ops, err := parseOperations(src)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = operationsTemplate.Execute(&buf, ops); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// operation describes an operation of the generated client.
+type operation struct {
+	ID         string
+	Method     string
+	Path       string
+	PathParams []string
+}
+
+var requestFunc = regexp.MustCompile(`^New(\w+)Request(WithBody)?$`)
+
+// parseOperations finds the request builders (NewXxxRequest and NewXxxRequestWithBody) in the generated client
+// and returns the method, path template and path parameters of each operation, sorted by operation ID.
+func parseOperations(src []byte) ([]operation, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	var ops []operation
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil {
+			continue
+		}
+		match := requestFunc.FindStringSubmatch(fn.Name.Name)
+		if match == nil {
+			continue
+		}
+		op := operation{ID: match[1]}
+		var pathFormat string
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch callName(call) {
+			case "runtime.StyleParamWithOptions":
+				if len(call.Args) > 2 && pathParam(call) {
+					op.PathParams = append(op.PathParams, stringLit(call.Args[2]))
+				}
+			case "fmt.Sprintf":
+				if pathFormat == "" && len(call.Args) > 0 {
+					pathFormat = stringLit(call.Args[0])
+				}
+			case "http.NewRequest":
+				if len(call.Args) > 0 {
+					if sel, ok := call.Args[0].(*ast.SelectorExpr); ok {
+						op.Method = strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+					}
+				}
+			}
+			return true
+		})
+		// NewXxxRequest functions with a JSON body call NewXxxRequestWithBody, which builds the request
+		if op.Method == "" {
+			continue
+		}
+		if strings.Count(pathFormat, "%s") != len(op.PathParams) {
+			return nil, fmt.Errorf("%s: path %q doesn't match path parameters %v", fn.Name.Name, pathFormat, op.PathParams)
+		}
+		op.Path = pathFormat
+		for _, param := range op.PathParams {
+			op.Path = strings.Replace(op.Path, "%s", "{"+param+"}", 1)
+		}
+		ops = append(ops, op)
+	}
+	slices.SortFunc(ops, func(a, b operation) int { return strings.Compare(a.ID, b.ID) })
+	return ops, nil
+}
+
+// pathParam returns true if the runtime.StyleParamWithOptions call styles a path parameter.
+func pathParam(call *ast.CallExpr) bool {
+	opts, ok := call.Args[len(call.Args)-1].(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range opts.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if sel, ok := kv.Value.(*ast.SelectorExpr); ok && sel.Sel.Name == "ParamLocationPath" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func callName(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return pkg.Name + "." + sel.Sel.Name
+}
+
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	s, _ := strconv.Unquote(lit.Value)
+	return s
+}
+
+var operationsTemplate = template.Must(template.New("operations").Parse(`// Code generated by internal/cmd/generate. DO NOT EDIT.
+
+package tado
+
+var operations = []Operation{
+{{- range . }}
+	{ID: "{{ .ID }}", Method: "{{ .Method }}", Path: "{{ .Path }}"},
+{{- end }}
+}
+`))
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseOperations(t *testing.T) {
+	src := []byte(`package tado
+
+func NewGetZoneRequest(server string, homeId HomeId, zoneId ZoneId) (*http.Request, error) {
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "homeId", homeId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath})
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "zoneId", zoneId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath})
+	headerParam0, err = runtime.StyleParamWithOptions("simple", false, "Content-Type", params.ContentType, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader})
+	operationPath := fmt.Sprintf("/homes/%s/zones/%s", pathParam0, pathParam1)
+	return http.NewRequest(http.MethodGet, queryURL.String(), nil)
+}
+
+func NewSetZoneRequest(server string, homeId HomeId, body SetZoneJSONRequestBody) (*http.Request, error) {
+	return NewSetZoneRequestWithBody(server, homeId, "application/json", bodyReader)
+}
+
+func NewSetZoneRequestWithBody(server string, homeId HomeId, contentType string, body io.Reader) (*http.Request, error) {
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "homeId", homeId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath})
+	operationPath := fmt.Sprintf("/homes/%s/zones", pathParam0)
+	return http.NewRequest(http.MethodPut, queryURL.String(), body)
+}
+`)
+	ops, err := parseOperations(src)
+	if err != nil {
+		t.Fatalf("parseOperations failed: %v", err)
+	}
+	want := []operation{
+		{ID: "GetZone", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}", PathParams: []string{"homeId", "zoneId"}},
+		{ID: "SetZone", Method: "PUT", Path: "/homes/{homeId}/zones", PathParams: []string{"homeId"}},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("got %d operations, want %d", len(ops), len(want))
+	}
+	for i := range want {
+		if ops[i].ID != want[i].ID || ops[i].Method != want[i].Method || ops[i].Path != want[i].Path || len(ops[i].PathParams) != len(want[i].PathParams) {
+			t.Errorf("got %+v, want %+v", ops[i], want[i])
+		}
+	}
+}
+
+// TestGenerated checks that the generated files are up to date.
+func TestGenerated(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "..", "client.gen.go"))
+	if err != nil {
+		t.Fatalf("failed to read client: %v", err)
+	}
+	want, err := generateOperations(src)
+	if err != nil {
+		t.Fatalf("generateOperations failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join("..", "..", "..", "operations.gen.go"))
+	if err != nil {
+		t.Fatalf("failed to read operations: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("operations.gen.go is out of date. run go generate")
+	}
+
+	if want, err = generateClient(src); err != nil {
+		t.Fatalf("generateClient failed: %v", err)
+	}
+	if got, err = os.ReadFile(filepath.Join("..", "..", "..", "tools", "client.gen.go")); err != nil {
+		t.Fatalf("failed to read tools client: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("tools/client.gen.go is out of date. run go generate")
+	}
+}
+
+func TestParseClient(t *testing.T) {
+	src := []byte(`package tado
+
+type ClientWithResponsesInterface interface {
+	// GetZoneWithResponse request
+	GetZoneWithResponse(ctx context.Context, homeId HomeId, zoneId ZoneId, reqEditors ...RequestEditorFn) (*GetZoneResponse, error)
+
+	// SetZoneWithBodyWithResponse request with any body
+	SetZoneWithBodyWithResponse(ctx context.Context, homeId HomeId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetZoneResponse, error)
+
+	// SetZoneWithResponse Update a zone.
+	SetZoneWithResponse(ctx context.Context, homeId HomeId, body SetZoneJSONRequestBody, reqEditors ...RequestEditorFn) (*SetZoneResponse, error)
+}
+
+type GetZoneResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Zone
+}
+
+type SetZoneResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+`)
+	methods, err := parseClient(src)
+	if err != nil {
+		t.Fatalf("parseClient failed: %v", err)
+	}
+	want := []method{
+		{Name: "GetZone", Doc: "GetZone request", Params: []param{{"homeId", "tado.HomeId"}, {"zoneId", "tado.ZoneId"}}, Payload: "[]tado.Zone"},
+		{Name: "SetZone", Doc: "SetZone Update a zone.", Params: []param{{"homeId", "tado.HomeId"}, {"body", "tado.SetZoneJSONRequestBody"}}},
+	}
+	if !reflect.DeepEqual(methods, want) {
+		t.Errorf("got %+v, want %+v", methods, want)
+	}
+}
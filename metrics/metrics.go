@@ -0,0 +1,168 @@
+// Package metrics records Prometheus metrics for the Tadoº API.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/clambin/tado/v2"
+	"github.com/clambin/tado/v2/oauth2store"
+	"github.com/clambin/tado/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/oauth2"
+)
+
+// A QuotaSource reports the remaining API quota. transport.RateLimiter implements this interface.
+type QuotaSource interface {
+	Quota() (quota transport.Quota, ok bool)
+}
+
+var _ prometheus.Collector = &Metrics{}
+
+// Metrics records metrics for all calls to the Tadoº API. It implements prometheus.Collector, so it can be
+// registered with a prometheus.Registerer.
+//
+// API calls are labeled by the ID of the generated operation (e.g. "GetZoneState"), rather than their URL.
+// Their code label contains the HTTP status code, "quota_exhausted" if the call was refused by a transport.RateLimiter,
+// or "error" if the call failed without a response.
+type Metrics struct {
+	// Quota, if set, reports the remaining API quota.
+	Quota QuotaSource
+
+	requests       *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+	retries        *prometheus.CounterVec
+	tokenRefreshes *prometheus.CounterVec
+	quotaRemaining *prometheus.Desc
+	quotaLimit     *prometheus.Desc
+}
+
+// New returns Metrics whose metric names start with namespace and subsystem (e.g. "tado_api_requests_total").
+func New(namespace, subsystem string) *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of Tado API calls by operation and status code",
+		}, []string{"operation", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Tado API calls by operation",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of retried Tado API calls by operation",
+		}, []string{"operation"}),
+		tokenRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "token_refreshes_total",
+			Help:      "Number of oauth2 token refreshes by result",
+		}, []string{"result"}),
+		quotaRemaining: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "quota_remaining"),
+			"Number of API calls left in the current quota window",
+			nil, nil,
+		),
+		quotaLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "quota_limit"),
+			"Number of API calls allowed per quota window",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.retries.Describe(ch)
+	m.tokenRefreshes.Describe(ch)
+	ch <- m.quotaRemaining
+	ch <- m.quotaLimit
+}
+
+// Collect implements the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.retries.Collect(ch)
+	m.tokenRefreshes.Collect(ch)
+	if m.Quota == nil {
+		return
+	}
+	if quota, ok := m.Quota.Quota(); ok {
+		ch <- prometheus.MustNewConstMetric(m.quotaRemaining, prometheus.GaugeValue, float64(quota.Remaining))
+		if quota.Limit > 0 {
+			ch <- prometheus.MustNewConstMetric(m.quotaLimit, prometheus.GaugeValue, float64(quota.Limit))
+		}
+	}
+}
+
+// Transport returns an http.RoundTripper that records the metrics of all requests it sends to next.
+// If next is nil, http.DefaultTransport is used.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		operation := operationID(req)
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		m.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(operation, code(resp, err)).Inc()
+		return resp, err
+	})
+}
+
+// OnRetry records a retried request. It can be used as transport.Retry's OnRetry function.
+func (m *Metrics) OnRetry(req *http.Request, _ int, _ *http.Response, _ error) {
+	m.retries.WithLabelValues(operationID(req)).Inc()
+}
+
+// Observer returns an oauth2store.Observer that records token refreshes.
+func (m *Metrics) Observer() oauth2store.Observer {
+	return oauth2store.Observer{
+		OnRefresh: func(_, _ *oauth2.Token) {
+			m.tokenRefreshes.WithLabelValues("success").Inc()
+		},
+		OnRefreshError: func(_ error) {
+			m.tokenRefreshes.WithLabelValues("error").Inc()
+		},
+		OnSaveError: func(_ *oauth2.Token, _ error) {
+			m.tokenRefreshes.WithLabelValues("save_error").Inc()
+		},
+	}
+}
+
+func operationID(req *http.Request) string {
+	if op, _, ok := tado.LookupOperation(req); ok {
+		return op.ID
+	}
+	return "unknown"
+}
+
+func code(resp *http.Response, err error) string {
+	switch {
+	case err == nil:
+		return strconv.Itoa(resp.StatusCode)
+	case errors.Is(err, transport.ErrQuotaExhausted):
+		return "quota_exhausted"
+	default:
+		return "error"
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
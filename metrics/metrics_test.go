@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2"
+	"github.com/clambin/tado/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/oauth2"
+)
+
+func TestMetrics(t *testing.T) {
+	var failed bool
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/me"):
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		case !failed:
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer s.Close()
+
+	m := New("tado", "api")
+	m.Quota = fakeQuota{Remaining: 100, Limit: 1000}
+	retry := transport.Retry{Next: m.Transport(nil), OnRetry: m.OnRetry, InitialBackoff: time.Millisecond}
+	client, err := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(&http.Client{Transport: &retry}))
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	if _, err = client.GetZoneStateWithResponse(t.Context(), 1, 2); err != nil {
+		t.Fatalf("GetZoneState failed: %v", err)
+	}
+	if _, err = client.GetMeWithResponse(t.Context()); err != nil {
+		t.Fatalf("GetMe failed: %v", err)
+	}
+	observer := m.Observer()
+	observer.OnRefresh(nil, &oauth2.Token{})
+	observer.OnRefreshError(errors.New("invalid_grant"))
+
+	want := `
+# HELP tado_api_quota_limit Number of API calls allowed per quota window
+# TYPE tado_api_quota_limit gauge
+tado_api_quota_limit 1000
+# HELP tado_api_quota_remaining Number of API calls left in the current quota window
+# TYPE tado_api_quota_remaining gauge
+tado_api_quota_remaining 100
+# HELP tado_api_requests_total Number of Tado API calls by operation and status code
+# TYPE tado_api_requests_total counter
+tado_api_requests_total{code="200",operation="GetZoneState"} 1
+tado_api_requests_total{code="401",operation="GetMe"} 1
+tado_api_requests_total{code="503",operation="GetZoneState"} 1
+# HELP tado_api_retries_total Number of retried Tado API calls by operation
+# TYPE tado_api_retries_total counter
+tado_api_retries_total{operation="GetZoneState"} 1
+# HELP tado_api_token_refreshes_total Number of oauth2 token refreshes by result
+# TYPE tado_api_token_refreshes_total counter
+tado_api_token_refreshes_total{result="error"} 1
+tado_api_token_refreshes_total{result="success"} 1
+`
+	if err = testutil.CollectAndCompare(m, strings.NewReader(want),
+		"tado_api_quota_limit",
+		"tado_api_quota_remaining",
+		"tado_api_requests_total",
+		"tado_api_retries_total",
+		"tado_api_token_refreshes_total",
+	); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(m, "tado_api_request_duration_seconds"); got != 2 {
+		t.Errorf("got %d histograms, want 2", got)
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want string
+	}{
+		{name: "response", resp: &http.Response{StatusCode: http.StatusOK}, want: "200"},
+		{name: "quota exhausted", err: &transport.QuotaExhaustedError{}, want: "quota_exhausted"},
+		{name: "error", err: errors.New("connection reset"), want: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := code(tt.resp, tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var _ QuotaSource = fakeQuota{}
+
+type fakeQuota transport.Quota
+
+func (f fakeQuota) Quota() (transport.Quota, bool) {
+	return transport.Quota(f), true
+}
@@ -0,0 +1,123 @@
+package oauth2store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var (
+	_ Storer  = DirStorer{}
+	_ Watcher = DirStorer{}
+)
+
+// DirStorer is a Storer that saves the token as a file in a directory, similar to how Kubernetes mounts a secret as a volume.
+//
+// DirStorer writes the token to a temporary file and renames it, so other processes sharing the directory (e.g. a sidecar container)
+// never see a partially written token. DirStorer is a Watcher: it reports when another process changes the token.
+type DirStorer struct {
+	// Dir is the directory holding the token
+	Dir string
+	// Name is the name of the file holding the token. Defaults to "token".
+	Name string
+}
+
+// NewDirTokenStore returns a TokenStore that saves the token in the file name in directory dir.
+func NewDirTokenStore(dir, name string) TokenStore {
+	return TokenStore{Storer: DirStorer{Dir: dir, Name: name}}
+}
+
+func (d DirStorer) Save(token []byte) (err error) {
+	f, err := os.CreateTemp(d.Dir, "."+d.name()+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if err = f.Chmod(0o600); err != nil {
+		return fmt.Errorf("chmod: %w", err)
+	}
+	if _, err = f.Write(token); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if err = os.Rename(f.Name(), d.path()); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+	// sync the directory so the rename is persisted
+	if dir, err := os.Open(d.Dir); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+	return nil
+}
+
+func (d DirStorer) Load() ([]byte, error) {
+	token, err := os.ReadFile(d.path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	return token, err
+}
+
+// Watch reports when the token file is created or changed. The returned channel is closed when the context is canceled.
+func (d DirStorer) Watch(ctx context.Context) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("fsnotify: %w", err)
+	}
+	// watch the directory rather than the file: renaming a file into place replaces the file being watched
+	if err = watcher.Add(d.Dir); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("fsnotify: %w", err)
+	}
+	ch := make(chan struct{}, 1)
+	go func() {
+		defer close(ch)
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Kubernetes updates a mounted volume by swapping the ..data symlink
+				if name := filepath.Base(event.Name); (name == d.name() || name == "..data") && event.Has(fsnotify.Create|fsnotify.Write) {
+					select {
+					case ch <- struct{}{}:
+					default:
+					}
+				}
+			case <-watcher.Errors:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (d DirStorer) name() string {
+	if d.Name == "" {
+		return "token"
+	}
+	return d.Name
+}
+
+func (d DirStorer) path() string {
+	return filepath.Join(d.Dir, d.name())
+}
@@ -0,0 +1,128 @@
+package oauth2store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestDirStorer(t *testing.T) {
+	dir := t.TempDir()
+	store := oauth2store.NewDirTokenStore(dir, "")
+
+	if _, err := store.Load(); !errors.Is(err, oauth2store.ErrNoToken) {
+		t.Fatalf("Load() error = %v, want %v", err, oauth2store.ErrNoToken)
+	}
+	if err := store.Save(&oauth2.Token{AccessToken: "access-token"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	token, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if token.AccessToken != "access-token" {
+		t.Errorf("got %q, want access-token", token.AccessToken)
+	}
+
+	// only the token file remains
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "token" {
+		t.Errorf("unexpected directory content: %v", entries)
+	}
+	if fileInfo, err := os.Stat(filepath.Join(dir, "token")); err != nil || fileInfo.Mode().Perm() != 0o600 {
+		t.Errorf("unexpected file mode: %v (err: %v)", fileInfo.Mode(), err)
+	}
+}
+
+func TestDirStorer_Watch(t *testing.T) {
+	storer := oauth2store.DirStorer{Dir: t.TempDir(), Name: "token.json"}
+	ch, err := storer.Watch(t.Context())
+	if err != nil {
+		t.Fatalf("Watch() failed: %v", err)
+	}
+
+	// changes to other files are ignored
+	if err = os.WriteFile(filepath.Join(storer.Dir, "other"), []byte("foo"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = storer.Save([]byte("token")); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for change")
+	}
+}
+
+func TestTokenSource_Watch(t *testing.T) {
+	dir := t.TempDir()
+	expiry := time.Now().Add(time.Hour)
+
+	// our token source
+	ts := oauth2store.TokenSource{
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "old-token", Expiry: expiry}),
+		TokenStore:  oauth2store.NewDirTokenStore(dir, "token"),
+		NewTokenSource: func(token *oauth2.Token) oauth2.TokenSource {
+			return oauth2.StaticTokenSource(token)
+		},
+	}
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	go func() { _ = ts.Watch(t.Context()) }()
+	// give the watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	// another process saves a newer token
+	other := oauth2store.NewDirTokenStore(dir, "token")
+	if err := other.Save(&oauth2.Token{AccessToken: "new-token", Expiry: expiry.Add(time.Minute)}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatalf("Token() failed: %v", err)
+		}
+		if token.AccessToken == "new-token" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for new token")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTokenSource_Reload(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	ts := oauth2store.TokenSource{
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "current-token", Expiry: expiry}),
+		TokenStore:  oauth2store.TokenStore{Storer: &fakeTokenStore{}},
+	}
+	if err := ts.Reload(); err == nil {
+		t.Fatal("Reload() without NewTokenSource should fail")
+	}
+	ts.NewTokenSource = func(token *oauth2.Token) oauth2.TokenSource { return oauth2.StaticTokenSource(token) }
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+
+	// an older token in the store is ignored
+	if err := ts.TokenStore.Save(&oauth2.Token{AccessToken: "older-token", Expiry: expiry.Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.Reload(); err != nil {
+		t.Fatalf("Reload() failed: %v", err)
+	}
+	if token, _ := ts.Token(); token.AccessToken != "current-token" {
+		t.Errorf("got %q, want current-token", token.AccessToken)
+	}
+}
@@ -0,0 +1,72 @@
+package oauth2store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"codeberg.org/clambin/go-crypt"
+)
+
+// ErrBadPassphrase is returned when an encrypted token store cannot be decrypted, i.e. the passphrase is wrong or the file is corrupted.
+var ErrBadPassphrase = errors.New("bad passphrase")
+
+var _ Storer = encryptedFile{}
+
+// encryptedFile is a Storer that saves the token in a file, encrypted with a passphrase.
+// It distinguishes between a missing file (ErrNoToken) and a file that can't be decrypted (ErrBadPassphrase).
+type encryptedFile struct {
+	Storer
+	path string
+}
+
+// NewEncryptedFileStorer returns a Storer that saves the token in a file, encrypted with the passphrase.
+// Load returns ErrNoToken if the file doesn't exist and ErrBadPassphrase if the file can't be decrypted.
+func NewEncryptedFileStorer(path, passphrase string) Storer {
+	return newEncryptedFile(path, passphrase)
+}
+
+func newEncryptedFile(path, passphrase string) encryptedFile {
+	return encryptedFile{Storer: crypt.New(path, passphrase), path: path}
+}
+
+func (e encryptedFile) Load() ([]byte, error) {
+	token, err := e.Storer.Load()
+	if err == nil {
+		return token, nil
+	}
+	if _, statErr := os.Stat(e.path); errors.Is(statErr, fs.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	return nil, fmt.Errorf("%w: %w", ErrBadPassphrase, err)
+}
+
+// Rekey re-encrypts the token in an encrypted file token store (see NewEncryptedFileTokenStore) with a new passphrase.
+// Afterward, the token store must be reopened with the new passphrase.
+func (t TokenStore) Rekey(oldPassphrase, newPassphrase string) (err error) {
+	if t.Path == "" {
+		return errors.New("not an encrypted file token store")
+	}
+	if t.Locker != nil {
+		if err = t.Locker.Lock(); err != nil {
+			return fmt.Errorf("lock: %w", err)
+		}
+		defer func() { _ = t.Locker.Unlock() }()
+	}
+	token, err := newEncryptedFile(t.Path, oldPassphrase).Load()
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so we don't lose the token if saving fails
+	tmpPath := t.Path + ".rekey"
+	if err = crypt.New(tmpPath, newPassphrase).Save(token); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("save: %w", err)
+	}
+	if err = os.Rename(tmpPath, t.Path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename: %w", err)
+	}
+	return nil
+}
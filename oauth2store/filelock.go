@@ -0,0 +1,59 @@
+package oauth2store
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// A Locker serializes access to a TokenStore shared by multiple processes.
+type Locker interface {
+	Lock() error
+	Unlock() error
+}
+
+var _ Locker = &FileLock{}
+
+// FileLock is a Locker that takes an advisory lock on a file. It can be used to share a TokenStore between processes on the same host:
+//
+//	store := oauth2store.NewEncryptedFileTokenStore("token.enc", passphrase)
+//	store.Locker = oauth2store.NewFileLock("token.enc.lock")
+type FileLock struct {
+	file *os.File
+	path string
+	// serializes goroutines within the same process
+	lock sync.Mutex
+}
+
+// NewFileLock returns a FileLock that locks the file at path. The file is created if it does not exist.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Lock takes the lock, waiting until it is released by any other process holding the lock.
+func (l *FileLock) Lock() error {
+	l.lock.Lock()
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		l.lock.Unlock()
+		return fmt.Errorf("open: %w", err)
+	}
+	if err = lockFile(f); err != nil {
+		_ = f.Close()
+		l.lock.Unlock()
+		return fmt.Errorf("lock: %w", err)
+	}
+	l.file = f
+	return nil
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	defer l.lock.Unlock()
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package oauth2store
+
+import (
+	"errors"
+	"os"
+)
+
+var errLockNotSupported = errors.New("file locking not supported on this platform")
+
+func lockFile(_ *os.File) error {
+	return errLockNotSupported
+}
+
+func unlockFile(_ *os.File) error {
+	return errLockNotSupported
+}
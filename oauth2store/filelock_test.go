@@ -0,0 +1,93 @@
+package oauth2store_test
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.lock")
+
+	var wg sync.WaitGroup
+	var holders, maxHolders atomic.Int32
+	for range 5 {
+		wg.Go(func() {
+			// each goroutine uses its own FileLock, as separate processes would
+			l := oauth2store.NewFileLock(path)
+			for range 10 {
+				if err := l.Lock(); err != nil {
+					t.Errorf("Lock() failed: %v", err)
+					return
+				}
+				if n := holders.Add(1); n > maxHolders.Load() {
+					maxHolders.Store(n)
+				}
+				time.Sleep(time.Millisecond)
+				holders.Add(-1)
+				if err := l.Unlock(); err != nil {
+					t.Errorf("Unlock() failed: %v", err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if got := maxHolders.Load(); got != 1 {
+		t.Errorf("got %d concurrent lock holders, want 1", got)
+	}
+}
+
+func TestTokenSource_Shared(t *testing.T) {
+	dir := t.TempDir()
+	var refreshes atomic.Int32
+	// refresher simulates the token endpoint: every refresh returns a new token
+	refresher := refresherFunc(func() (*oauth2.Token, error) {
+		n := refreshes.Add(1)
+		return &oauth2.Token{AccessToken: "token-" + strconv.Itoa(int(n)), Expiry: time.Now().Add(time.Hour)}, nil
+	})
+	newTokenSource := func(token *oauth2.Token) oauth2.TokenSource {
+		return oauth2.ReuseTokenSource(token, refresher)
+	}
+	newSharedTokenSource := func() *oauth2store.TokenSource {
+		store := oauth2store.NewDirTokenStore(dir, "token")
+		store.Locker = oauth2store.NewFileLock(filepath.Join(dir, "token.lock"))
+		// both processes start with the same, expired, token
+		expired := &oauth2.Token{AccessToken: "token-0", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)}
+		return &oauth2store.TokenSource{
+			TokenSource:    newTokenSource(expired),
+			TokenStore:     store,
+			NewTokenSource: newTokenSource,
+		}
+	}
+	ts1 := newSharedTokenSource()
+	ts2 := newSharedTokenSource()
+
+	// the first process refreshes the token
+	token1, err := ts1.Token()
+	if err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	// the second process uses the refreshed token, rather than refreshing the token itself
+	token2, err := ts2.Token()
+	if err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	if token1.AccessToken != token2.AccessToken {
+		t.Errorf("processes use different tokens: %q vs %q", token1.AccessToken, token2.AccessToken)
+	}
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("got %d refreshes, want 1", got)
+	}
+}
+
+type refresherFunc func() (*oauth2.Token, error)
+
+func (f refresherFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
@@ -0,0 +1,220 @@
+package oauth2store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Keyring stores secrets in a password manager, e.g. the operating system's keyring.
+// Get returns ErrNoToken if the keyring doesn't hold a secret for the service and account.
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+}
+
+var _ Storer = KeyringStorer{}
+
+// KeyringStorer is a Storer that saves the token in a Keyring, so the application doesn't need a passphrase to protect the token.
+type KeyringStorer struct {
+	Keyring Keyring
+	Service string
+	Account string
+}
+
+// NewKeyringTokenStore returns a TokenStore that saves the token in the keyring, under the provided service and account.
+func NewKeyringTokenStore(keyring Keyring, service, account string) TokenStore {
+	return TokenStore{
+		Storer: KeyringStorer{Keyring: keyring, Service: service, Account: account},
+	}
+}
+
+func (k KeyringStorer) Save(token []byte) error {
+	return k.Keyring.Set(k.Service, k.Account, string(token))
+}
+
+func (k KeyringStorer) Load() ([]byte, error) {
+	secret, err := k.Keyring.Get(k.Service, k.Account)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(secret), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var _ Keyring = SecretService{}
+
+// SecretService is a Keyring that stores secrets through the freedesktop.org Secret Service API (e.g. GNOME Keyring, KWallet).
+// It requires the secret-tool command, which is part of libsecret.
+type SecretService struct{}
+
+func (SecretService) Get(service, account string) (string, error) {
+	secret, stderr, err := run(nil, "secret-tool", "lookup", "service", service, "account", account)
+	// secret-tool fails without an error message if the secret doesn't exist
+	if (err == nil && secret == "") || (isExitError(err) && stderr == "") {
+		return "", ErrNoToken
+	}
+	return secret, commandError("secret-tool", stderr, err)
+}
+
+func (SecretService) Set(service, account, secret string) error {
+	label := service + " (" + account + ")"
+	_, stderr, err := run(strings.NewReader(secret), "secret-tool", "store", "--label", label, "service", service, "account", account)
+	return commandError("secret-tool", stderr, err)
+}
+
+var _ Keyring = Pass{}
+
+// Pass is a Keyring that stores secrets in [pass], the standard unix password manager, or a compatible implementation.
+// Secrets are stored as service/account.
+//
+// [pass]: https://www.passwordstore.org
+type Pass struct {
+	// Command to run. Defaults to "pass".
+	Command string
+}
+
+func (p Pass) Get(service, account string) (string, error) {
+	secret, stderr, err := run(nil, p.command(), "show", service+"/"+account)
+	if isExitError(err) && strings.Contains(stderr, "is not in the password store") {
+		return "", ErrNoToken
+	}
+	return secret, commandError(p.command(), stderr, err)
+}
+
+func (p Pass) Set(service, account, secret string) error {
+	_, stderr, err := run(strings.NewReader(secret+"\n"), p.command(), "insert", "--multiline", "--force", service+"/"+account)
+	return commandError(p.command(), stderr, err)
+}
+
+func (p Pass) command() string {
+	if p.Command == "" {
+		return "pass"
+	}
+	return p.Command
+}
+
+// run runs a command and returns its output
+func run(stdin *strings.Reader, name string, args ...string) (string, string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return strings.TrimSuffix(stdout.String(), "\n"), strings.TrimSpace(stderr.String()), err
+}
+
+func commandError(name string, stderr string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case stderr == "":
+		return fmt.Errorf("%s: %w", name, err)
+	default:
+		return fmt.Errorf("%s: %w: %s", name, err, stderr)
+	}
+}
+
+func isExitError(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr)
+}
+
+var _ Keyring = &MemoryKeyring{}
+
+// MemoryKeyring is a Keyring that keeps its secrets in memory. It is intended as a test double for the other Keyring implementations.
+type MemoryKeyring struct {
+	secrets map[string]string
+	lock    sync.RWMutex
+}
+
+func (m *MemoryKeyring) Get(service, account string) (string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	secret, ok := m.secrets[service+"/"+account]
+	if !ok {
+		return "", ErrNoToken
+	}
+	return secret, nil
+}
+
+func (m *MemoryKeyring) Set(service, account, secret string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.secrets == nil {
+		m.secrets = make(map[string]string)
+	}
+	m.secrets[service+"/"+account] = secret
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var _ Storer = FallbackStorer{}
+
+// FallbackStorer is a Storer that tries a list of Storers in order of preference, e.g. a keyring, followed by an encrypted file:
+//
+//	store := oauth2store.TokenStore{
+//		Storer: oauth2store.FallbackStorer{
+//			oauth2store.KeyringStorer{Keyring: oauth2store.SecretService{}, Service: "tado", Account: "user@example.com"},
+//			oauth2store.NewEncryptedFileStorer("token.enc", passphrase),
+//		},
+//	}
+//
+// Save saves the token in the first Storer that succeeds. Load loads the token from all Storers and returns the most recently saved one,
+// so that a token saved in a fallback Storer (e.g. because the keyring was temporarily unavailable) takes precedence over an older token
+// in a preferred Storer. If the Storers hold tokens saved at the same time, Load returns the token of the first Storer.
+// If no Storer holds a token, and at least one Storer reports ErrNoToken, Load returns an error that wraps ErrNoToken.
+type FallbackStorer []Storer
+
+func (f FallbackStorer) Save(token []byte) error {
+	var errs error
+	for _, s := range f {
+		err := s.Save(token)
+		if err == nil {
+			return nil
+		}
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+func (f FallbackStorer) Load() ([]byte, error) {
+	if len(f) == 0 {
+		return nil, ErrNoToken
+	}
+	var newest []byte
+	var newestSavedAt time.Time
+	var errs error
+	for _, s := range f {
+		token, err := s.Load()
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		// tokens saved by older versions of TokenStore have no save time: any other token is more recent
+		var e envelope
+		_ = json.Unmarshal(token, &e)
+		if newest == nil || e.SavedAt.After(newestSavedAt) {
+			newest, newestSavedAt = token, e.SavedAt
+		}
+	}
+	if newest == nil {
+		// if a Storer reports that it has no token, the application needs a new token,
+		// even if other Storers failed (e.g. the keyring is unavailable)
+		if errors.Is(errs, ErrNoToken) {
+			return nil, fmt.Errorf("%w: %w", ErrNoToken, errs)
+		}
+		return nil, errs
+	}
+	return newest, nil
+}
@@ -0,0 +1,170 @@
+package oauth2store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestKeyringStorer(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyring oauth2store.Keyring
+	}{
+		{"memory", &oauth2store.MemoryKeyring{}},
+		{"secret service", oauth2store.SecretService{}},
+		{"pass", oauth2store.Pass{}},
+	}
+
+	installFakeKeyringCommands(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := oauth2store.NewKeyringTokenStore(tt.keyring, "tado", tt.name)
+			if _, err := store.Load(); !errors.Is(err, oauth2store.ErrNoToken) {
+				t.Fatalf("Load() error = %v, want %v", err, oauth2store.ErrNoToken)
+			}
+			if err := store.Save(&oauth2.Token{AccessToken: "access-token"}); err != nil {
+				t.Fatalf("Save() failed: %v", err)
+			}
+			token, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load() failed: %v", err)
+			}
+			if token.AccessToken != "access-token" {
+				t.Errorf("got %q, want access-token", token.AccessToken)
+			}
+		})
+	}
+}
+
+func TestFallbackStorer(t *testing.T) {
+	keyring := oauth2store.KeyringStorer{Keyring: &oauth2store.MemoryKeyring{}, Service: "tado", Account: "foo"}
+	var file fakeTokenStore
+
+	// no storers
+	if _, err := (oauth2store.FallbackStorer{}).Load(); !errors.Is(err, oauth2store.ErrNoToken) {
+		t.Fatalf("Load() error = %v, want %v", err, oauth2store.ErrNoToken)
+	}
+
+	// the keyring is unavailable: token is saved to file
+	unavailable := oauth2store.KeyringStorer{Keyring: failingKeyring{}}
+	store := oauth2store.TokenStore{Storer: oauth2store.FallbackStorer{unavailable, &file}}
+	if err := store.Save(&oauth2.Token{AccessToken: "file-token"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if token, err := store.Load(); err != nil || token.AccessToken != "file-token" {
+		t.Fatalf("Load() = (%v, %v), want file-token", token, err)
+	}
+
+	// the keyring is available: the token is saved in the keyring and loaded from the keyring first
+	store = oauth2store.TokenStore{Storer: oauth2store.FallbackStorer{keyring, &file}}
+	if token, err := store.Load(); err != nil || token.AccessToken != "file-token" {
+		t.Fatalf("Load() = (%v, %v), want file-token", token, err)
+	}
+	if err := store.Save(&oauth2.Token{AccessToken: "keyring-token"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if token, err := store.Load(); err != nil || token.AccessToken != "keyring-token" {
+		t.Fatalf("Load() = (%v, %v), want keyring-token", token, err)
+	}
+
+	// all storers fail
+	store = oauth2store.TokenStore{Storer: oauth2store.FallbackStorer{unavailable}}
+	if err := store.Save(&oauth2.Token{AccessToken: "token"}); err == nil {
+		t.Error("Save() should fail")
+	}
+	if _, err := store.Load(); err == nil || errors.Is(err, oauth2store.ErrNoToken) {
+		t.Errorf("Load() error = %v, want an error other than %v", err, oauth2store.ErrNoToken)
+	}
+
+	// the keyring is unavailable and the file holds no token yet
+	store = oauth2store.TokenStore{Storer: oauth2store.FallbackStorer{unavailable, &fakeTokenStore{}}}
+	if _, err := store.Load(); !errors.Is(err, oauth2store.ErrNoToken) {
+		t.Errorf("Load() error = %v, want %v", err, oauth2store.ErrNoToken)
+	}
+}
+
+func TestFallbackStorer_KeyringRecovers(t *testing.T) {
+	keyring := flakyKeyring{Keyring: &oauth2store.MemoryKeyring{}}
+	var file fakeTokenStore
+	store := oauth2store.TokenStore{Storer: oauth2store.FallbackStorer{
+		oauth2store.KeyringStorer{Keyring: &keyring, Service: "tado", Account: "foo"},
+		&file,
+	}}
+
+	for _, step := range []struct {
+		token    string
+		failures int
+	}{
+		{token: "token-1"},
+		// keyring fails once: the token is saved in the file, and takes precedence over the older token in the keyring
+		{token: "token-2", failures: 1},
+		// keyring recovers
+		{token: "token-3"},
+	} {
+		keyring.failures = step.failures
+		if err := store.Save(&oauth2.Token{AccessToken: step.token, RefreshToken: step.token}); err != nil {
+			t.Fatalf("Save(%s) failed: %v", step.token, err)
+		}
+		if token, err := store.Load(); err != nil || token.AccessToken != step.token {
+			t.Fatalf("Load() = (%v, %v), want %s", token, err, step.token)
+		}
+	}
+}
+
+// flakyKeyring fails the next failures calls to Set.
+type flakyKeyring struct {
+	oauth2store.Keyring
+	failures int
+}
+
+func (f *flakyKeyring) Set(service, account, secret string) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("keyring unavailable")
+	}
+	return f.Keyring.Set(service, account, secret)
+}
+
+var _ oauth2store.Keyring = failingKeyring{}
+
+type failingKeyring struct{}
+
+func (failingKeyring) Get(_, _ string) (string, error) { return "", errors.New("keyring unavailable") }
+func (failingKeyring) Set(_, _, _ string) error        { return errors.New("keyring unavailable") }
+
+// installFakeKeyringCommands installs fake secret-tool and pass commands that store their secrets in a temporary directory.
+func installFakeKeyringCommands(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake keyring commands require a unix shell")
+	}
+	binDir := t.TempDir()
+	dataDir := t.TempDir()
+	commands := map[string]string{
+		"secret-tool": `#!/bin/sh
+case "$1" in
+store) cat > "` + dataDir + `/$5-$7" ;;
+lookup) [ -f "` + dataDir + `/$3-$5" ] || exit 1; cat "` + dataDir + `/$3-$5" ;;
+esac
+`,
+		"pass": `#!/bin/sh
+case "$1" in
+insert) mkdir -p "$(dirname "` + dataDir + `/$4")"; cat > "` + dataDir + `/$4" ;;
+show) [ -f "` + dataDir + `/$2" ] || { echo "Error: $2 is not in the password store." >&2; exit 1; }; cat "` + dataDir + `/$2" ;;
+esac
+`,
+	}
+	for name, script := range commands {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0o755); err != nil {
+			t.Fatalf("failed to install %s: %v", name, err)
+		}
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
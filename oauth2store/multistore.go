@@ -0,0 +1,108 @@
+package oauth2store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// A MultiTokenStore holds the tokens of multiple accounts in a single Storer, e.g. one encrypted file.
+// Use For to get the TokenStore of a single account.
+//
+// MultiTokenStore serializes access to its Storer within a single process. It should not be shared between processes.
+type MultiTokenStore struct {
+	Storer Storer
+	lock   sync.Mutex
+}
+
+// NewEncryptedFileMultiTokenStore returns a MultiTokenStore that stores all tokens in a single encrypted file.
+func NewEncryptedFileMultiTokenStore(path, passphrase string) *MultiTokenStore {
+	return &MultiTokenStore{Storer: newEncryptedFile(path, passphrase)}
+}
+
+// For returns the TokenStore for the provided account.
+func (m *MultiTokenStore) For(account string) TokenStore {
+	return TokenStore{
+		Storer:  accountStorer{store: m, account: account},
+		Account: account,
+	}
+}
+
+// Accounts returns the accounts that have a token in the store.
+func (m *MultiTokenStore) Accounts() ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	tokens, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]string, 0, len(tokens))
+	for account := range tokens {
+		accounts = append(accounts, account)
+	}
+	slices.Sort(accounts)
+	return accounts, nil
+}
+
+func (m *MultiTokenStore) save(account string, token []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	tokens, err := m.load()
+	if err != nil {
+		return err
+	}
+	tokens[account] = token
+	bytes, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("json: %w", err)
+	}
+	return m.Storer.Save(bytes)
+}
+
+func (m *MultiTokenStore) loadAccount(account string) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	tokens, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[account]
+	if !ok {
+		return nil, ErrNoToken
+	}
+	return token, nil
+}
+
+// load returns the tokens by account. An empty store returns an empty map.
+func (m *MultiTokenStore) load() (map[string]json.RawMessage, error) {
+	tokens := make(map[string]json.RawMessage)
+	bytes, err := m.Storer.Load()
+	if errors.Is(err, ErrNoToken) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(bytes, &tokens); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	return tokens, nil
+}
+
+var _ Storer = accountStorer{}
+
+// accountStorer is the Storer for a single account in a MultiTokenStore.
+type accountStorer struct {
+	store   *MultiTokenStore
+	account string
+}
+
+func (a accountStorer) Save(token []byte) error {
+	return a.store.save(a.account, token)
+}
+
+func (a accountStorer) Load() ([]byte, error) {
+	return a.store.loadAccount(a.account)
+}
@@ -0,0 +1,56 @@
+package oauth2store_test
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestMultiTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	store := oauth2store.NewEncryptedFileMultiTokenStore(path, "passphrase")
+
+	if accounts, err := store.Accounts(); err != nil || len(accounts) != 0 {
+		t.Fatalf("Accounts() = (%v, %v), want no accounts", accounts, err)
+	}
+	if _, err := store.For("home").Load(); !errors.Is(err, oauth2store.ErrNoToken) {
+		t.Fatalf("Load() error = %v, want %v", err, oauth2store.ErrNoToken)
+	}
+
+	for _, account := range []string{"home", "office"} {
+		if err := store.For(account).Save(&oauth2.Token{AccessToken: account + "-token"}); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	// reopen the store
+	store = oauth2store.NewEncryptedFileMultiTokenStore(path, "passphrase")
+	accounts, err := store.Accounts()
+	if err != nil {
+		t.Fatalf("Accounts() failed: %v", err)
+	}
+	if want := []string{"home", "office"}; !slices.Equal(accounts, want) {
+		t.Errorf("got accounts %v, want %v", accounts, want)
+	}
+	for _, account := range accounts {
+		token, err := store.For(account).Load()
+		if err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+		if want := account + "-token"; token.AccessToken != want {
+			t.Errorf("got %q, want %q", token.AccessToken, want)
+		}
+		if metadata, _ := store.For(account).Metadata(); metadata.Account != account {
+			t.Errorf("got account %q, want %q", metadata.Account, account)
+		}
+	}
+
+	// wrong passphrase
+	if _, err = oauth2store.NewEncryptedFileMultiTokenStore(path, "bad-passphrase").Accounts(); !errors.Is(err, oauth2store.ErrBadPassphrase) {
+		t.Errorf("Accounts() error = %v, want %v", err, oauth2store.ErrBadPassphrase)
+	}
+}
@@ -0,0 +1,104 @@
+package oauth2store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+
+	"golang.org/x/oauth2"
+)
+
+// An Observer is notified of a TokenSource's token lifecycle events. Any of its functions may be nil.
+type Observer struct {
+	// OnLoad is called when the TokenSource loads a token from its TokenStore.
+	OnLoad func(token *oauth2.Token, err error)
+	// OnRefresh is called when the underlying TokenSource returns a new token. For the first token, old is nil.
+	OnRefresh func(old, new *oauth2.Token)
+	// OnRefreshError is called when the underlying TokenSource fails to return a token.
+	OnRefreshError func(err error)
+	// OnSaveError is called when a new token can't be saved in the TokenStore.
+	OnSaveError func(token *oauth2.Token, err error)
+}
+
+// AuditLog returns an Observer that logs all token lifecycle events to logger. Tokens are redacted: the log only
+// contains their type, expiry and a fingerprint, so changes to the access and refresh tokens can be traced.
+func AuditLog(logger *slog.Logger) Observer {
+	return Observer{
+		OnLoad: func(token *oauth2.Token, err error) {
+			if err != nil {
+				logger.Warn("failed to load token", "err", err)
+				return
+			}
+			logger.Info("token loaded", "token", RedactedToken{token})
+		},
+		OnRefresh: func(old, new *oauth2.Token) {
+			logger.Info("token refreshed", "old", RedactedToken{old}, "new", RedactedToken{new})
+		},
+		OnRefreshError: func(err error) {
+			logger.Warn("failed to refresh token", "err", err)
+		},
+		OnSaveError: func(token *oauth2.Token, err error) {
+			logger.Error("failed to save token", "token", RedactedToken{token}, "err", err)
+		},
+	}
+}
+
+var _ slog.LogValuer = RedactedToken{}
+
+// RedactedToken logs an oauth2.Token without revealing its access and refresh tokens.
+type RedactedToken struct {
+	*oauth2.Token
+}
+
+func (r RedactedToken) LogValue() slog.Value {
+	if r.Token == nil {
+		return slog.StringValue("none")
+	}
+	return slog.GroupValue(
+		slog.String("type", r.Type()),
+		slog.Time("expiry", r.Expiry),
+		slog.String("access_token", fingerprint(r.AccessToken)),
+		slog.String("refresh_token", fingerprint(r.RefreshToken)),
+	)
+}
+
+// fingerprint returns a short hash of a secret: enough to tell two secrets apart, without revealing the secret.
+func fingerprint(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:4])
+}
+
+func (ts *TokenSource) notifyLoad(token *oauth2.Token, err error) {
+	for _, o := range ts.Observers {
+		if o.OnLoad != nil {
+			o.OnLoad(token, err)
+		}
+	}
+}
+
+func (ts *TokenSource) notifyRefresh(old, new *oauth2.Token) {
+	for _, o := range ts.Observers {
+		if o.OnRefresh != nil {
+			o.OnRefresh(old, new)
+		}
+	}
+}
+
+func (ts *TokenSource) notifyRefreshError(err error) {
+	for _, o := range ts.Observers {
+		if o.OnRefreshError != nil {
+			o.OnRefreshError(err)
+		}
+	}
+}
+
+func (ts *TokenSource) notifySaveError(token *oauth2.Token, err error) {
+	for _, o := range ts.Observers {
+		if o.OnSaveError != nil {
+			o.OnSaveError(token, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package oauth2store_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/clambin/tado/v2/oauth2store"
+	"golang.org/x/oauth2"
+)
+
+func TestTokenSource_Observers(t *testing.T) {
+	var loads, refreshes, refreshErrors, saveErrors int
+	observer := oauth2store.Observer{
+		OnLoad:         func(_ *oauth2.Token, _ error) { loads++ },
+		OnRefresh:      func(_, _ *oauth2.Token) { refreshes++ },
+		OnRefreshError: func(_ error) { refreshErrors++ },
+		OnSaveError:    func(_ *oauth2.Token, _ error) { saveErrors++ },
+	}
+	storer := failingStorer{fakeTokenStore: &fakeTokenStore{}}
+	ts := oauth2store.TokenSource{
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		TokenStore:  oauth2store.TokenStore{Storer: &storer},
+		Observers:   []oauth2store.Observer{observer, {}},
+	}
+
+	// new token: saved
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	// same token: not saved
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	if _, err := ts.Load(); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	// new token: save fails
+	storer.fail = true
+	ts.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "new-token"})
+	if _, err := ts.Token(); err == nil {
+		t.Fatal("Token() should fail")
+	}
+	// token source fails
+	ts.TokenSource = failingTokenSource{}
+	if _, err := ts.Token(); err == nil {
+		t.Fatal("Token() should fail")
+	}
+
+	if loads != 1 || refreshes != 2 || refreshErrors != 1 || saveErrors != 1 {
+		t.Errorf("unexpected notifications: loads=%d, refreshes=%d, refreshErrors=%d, saveErrors=%d", loads, refreshes, refreshErrors, saveErrors)
+	}
+}
+
+func TestTokenSource_SetToken(t *testing.T) {
+	var refreshes int
+	var store fakeTokenStore
+	ts := oauth2store.TokenSource{
+		TokenStore:     oauth2store.TokenStore{Storer: &store},
+		NewTokenSource: func(token *oauth2.Token) oauth2.TokenSource { return oauth2.StaticTokenSource(token) },
+		Observers:      []oauth2store.Observer{{OnRefresh: func(_, _ *oauth2.Token) { refreshes++ }}},
+	}
+
+	// a loaded token is neither reported as refreshed, nor saved again
+	if err := ts.SetToken(&oauth2.Token{AccessToken: "token"}); err != nil {
+		t.Fatalf("SetToken() failed: %v", err)
+	}
+	if token, err := ts.Token(); err != nil || token.AccessToken != "token" {
+		t.Fatalf("Token() = (%v, %v), want token", token, err)
+	}
+	if refreshes != 0 || store.saveCount.Load() != 0 {
+		t.Errorf("got %d refreshes, %d saves, want none", refreshes, store.saveCount.Load())
+	}
+
+	// NewTokenSource is required
+	if err := (&oauth2store.TokenSource{}).SetToken(&oauth2.Token{}); err == nil {
+		t.Error("SetToken() should fail without NewTokenSource")
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	ts := oauth2store.TokenSource{
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-access-token", RefreshToken: "secret-refresh-token"}),
+		TokenStore:  oauth2store.TokenStore{Storer: &fakeTokenStore{}},
+		Observers:   []oauth2store.Observer{oauth2store.AuditLog(logger)},
+	}
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	if _, err := ts.Load(); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	for _, want := range []string{`msg="token refreshed" old=none new.type=Bearer`, `msg="token loaded"`, "access_token=", "refresh_token="} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("audit log does not contain %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "secret") {
+		t.Errorf("audit log contains secrets:\n%s", out.String())
+	}
+}
+
+var _ oauth2store.Storer = &failingStorer{}
+
+type failingStorer struct {
+	*fakeTokenStore
+	fail bool
+}
+
+func (f *failingStorer) Save(bytes []byte) error {
+	if f.fail {
+		return errors.New("failed to save token")
+	}
+	return f.fakeTokenStore.Save(bytes)
+}
+
+var _ oauth2.TokenSource = failingTokenSource{}
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("invalid_grant")
+}
@@ -1,7 +1,12 @@
 package oauth2store
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -13,27 +18,126 @@ var _ oauth2.TokenSource = &TokenSource{}
 // The main use case is to persist tokens that require manual action to create (e.g., device authentication flow),
 // so that a previously created token may be reused if the application is restarted.
 //
+// If the TokenStore is shared with other processes, TokenSource can switch to a newer token saved by another process
+// (see Reload and Watch), rather than refreshing (and overwriting) that token with its own, stale, token.
+//
 // [oauth2.TokenSource]: https://pkg.go.dev/golang.org/x/oauth2#TokenSource
 type TokenSource struct {
 	oauth2.TokenSource
 	currentToken atomic.Pointer[oauth2.Token]
 	TokenStore
+	// NewTokenSource returns an oauth2.TokenSource for the provided token, e.g. oauth2.Config's TokenSource method.
+	// Reload uses it to replace the underlying TokenSource when the TokenStore holds a newer token.
+	NewTokenSource func(*oauth2.Token) oauth2.TokenSource
+	// Observers are notified of token lifecycle events, e.g. to log them (see AuditLog).
+	Observers []Observer
+	lock      sync.Mutex
 }
 
 // Token implements the oauth2.TokenSource interface. It gets a token from the underlying TokenSource and,
 // if the token is new, stores the token in its TokenStore.
 func (ts *TokenSource) Token() (token *oauth2.Token, err error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	// if the token store is shared, only one process may refresh the token at a time.
+	// before refreshing, check if another process has already refreshed the token.
+	if ts.Locker != nil && !validFor(ts.currentToken.Load(), lockMargin) {
+		if err = ts.Locker.Lock(); err != nil {
+			return nil, fmt.Errorf("lock: %w", err)
+		}
+		defer func() { _ = ts.Locker.Unlock() }()
+		_ = ts.reload()
+	}
+
 	// get an active token from the underlying token source
 	if token, err = ts.TokenSource.Token(); err != nil {
+		ts.notifyRefreshError(err)
 		return nil, err
 	}
 
 	// if the token is new or has changed, save it so we can load it on startup
 	currentToken := ts.currentToken.Load()
 	if currentToken == nil || currentToken.AccessToken != token.AccessToken {
+		ts.notifyRefresh(currentToken, token)
 		if err = ts.Save(token); err == nil {
 			ts.currentToken.Store(token)
+		} else {
+			ts.notifySaveError(token, err)
 		}
 	}
 	return token, err
 }
+
+// Load loads the token from the TokenStore and notifies the Observers.
+func (ts *TokenSource) Load() (*oauth2.Token, error) {
+	token, err := ts.TokenStore.Load()
+	ts.notifyLoad(token, err)
+	return token, err
+}
+
+// Reload loads the token from the TokenStore. If it is newer than the current token (i.e., it was saved by another process),
+// Reload replaces the underlying TokenSource with one created by NewTokenSource.
+func (ts *TokenSource) Reload() error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	return ts.reload()
+}
+
+func (ts *TokenSource) reload() error {
+	if ts.NewTokenSource == nil {
+		return errors.New("NewTokenSource not set")
+	}
+	token, err := ts.Load()
+	if err != nil {
+		return err
+	}
+	if currentToken := ts.currentToken.Load(); currentToken != nil && (currentToken.AccessToken == token.AccessToken || !token.Expiry.After(currentToken.Expiry)) {
+		return nil
+	}
+	return ts.setToken(token)
+}
+
+// SetToken sets the current token to a token loaded from the TokenStore and replaces the underlying TokenSource with one
+// created by NewTokenSource. Since the token is already stored, Token doesn't save it again, nor reports it as refreshed,
+// until the underlying TokenSource returns a new token.
+func (ts *TokenSource) SetToken(token *oauth2.Token) error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	return ts.setToken(token)
+}
+
+func (ts *TokenSource) setToken(token *oauth2.Token) error {
+	if ts.NewTokenSource == nil {
+		return errors.New("NewTokenSource not set")
+	}
+	ts.TokenSource = ts.NewTokenSource(token)
+	ts.currentToken.Store(token)
+	return nil
+}
+
+// lockMargin is the time before a token expires when TokenSource takes the TokenStore's lock. It must be larger than
+// the margin the oauth2 package uses to refresh tokens before they expire (10s).
+const lockMargin = time.Minute
+
+// validFor returns true if the token is valid for at least the provided duration.
+func validFor(token *oauth2.Token, d time.Duration) bool {
+	return token != nil && (token.Expiry.IsZero() || time.Until(token.Expiry) > d)
+}
+
+// Watch calls Reload every time the TokenStore reports that the token has changed, until the context is canceled.
+// If the TokenStore's Storer does not implement Watcher, Watch returns immediately.
+func (ts *TokenSource) Watch(ctx context.Context) error {
+	watcher, ok := ts.Storer.(Watcher)
+	if !ok {
+		return nil
+	}
+	ch, err := watcher.Watch(ctx)
+	if err != nil {
+		return err
+	}
+	for range ch {
+		_ = ts.Reload()
+	}
+	return nil
+}
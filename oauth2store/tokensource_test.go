@@ -71,5 +71,9 @@ func (f *fakeTokenStore) Save(bytes []byte) error {
 }
 
 func (f *fakeTokenStore) Load() ([]byte, error) {
-	return f.token.Load().([]byte), nil
+	token, ok := f.token.Load().([]byte)
+	if !ok {
+		return nil, oauth2store.ErrNoToken
+	}
+	return token, nil
 }
@@ -1,12 +1,13 @@
 package oauth2store
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
-	"codeberg.org/clambin/go-crypt"
 	"golang.org/x/oauth2"
 )
 
@@ -16,29 +17,78 @@ type Storer interface {
 	Load() ([]byte, error)
 }
 
+// A Watcher is a Storer that reports when the stored token changes, e.g. because it was saved by another process.
+type Watcher interface {
+	Watch(ctx context.Context) (<-chan struct{}, error)
+}
+
 // A TokenStore saves and loads oauth2 Tokens. In conjunction with this package's TokenSource,
 // it allows calling applications to persist tokens and reuse them between runs.
 //
 // The main use case is to persist tokens that require manual action to create (e.g. device authentication flow), so that
 // a previously created token may be reused if the application is restarted.
+//
+// Besides the token, TokenStore saves the token's Metadata: when it was saved, when its refresh token was issued and,
+// if Account is set, the account it belongs to.
 type TokenStore struct {
 	Storer
+	// Path of the file holding the token. Only used to determine when tokens saved by older versions of TokenStore were saved.
 	Path string
+	// Account the token belongs to. If set, Load only returns tokens saved for the same account.
+	Account string
+	// Locker, if set, allows multiple processes to share the TokenStore: before refreshing the token, TokenSource takes the lock
+	// and loads the token from the TokenStore, so it uses the token saved by another process, rather than refreshing its own.
+	Locker Locker
 }
 
 // NewEncryptedFileTokenStore returns a TokenStore that stored the encrypted token to disk.
-// Expired tokens are not loaded.
+// Expired tokens are not loaded. If the token can't be decrypted, Load returns ErrBadPassphrase.
 func NewEncryptedFileTokenStore(path, passphrase string) TokenStore {
 	return TokenStore{
 		Path:   path,
-		Storer: crypt.New(path, passphrase),
+		Storer: newEncryptedFile(path, passphrase),
 	}
 }
 
+// ErrNoToken is returned when a Storer doesn't hold a token.
+var ErrNoToken = errors.New("no token")
+
+// ErrAccountMismatch is returned when the stored token belongs to a different account than the TokenStore's Account.
+var ErrAccountMismatch = errors.New("token belongs to a different account")
+
+// Metadata contains information about a token saved in a TokenStore.
+type Metadata struct {
+	// SavedAt is the time the token was last saved.
+	SavedAt time.Time `json:"saved_at"`
+	// RefreshTokenIssuedAt is the time the token's refresh token was first saved.
+	RefreshTokenIssuedAt time.Time `json:"refresh_token_issued_at"`
+	// Account is the account the token belongs to.
+	Account string `json:"account,omitempty"`
+}
+
+// envelope is the stored representation of a token.
+type envelope struct {
+	Token *oauth2.Token `json:"token"`
+	Metadata
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func (t TokenStore) Save(token *oauth2.Token) error {
-	bytes, err := json.Marshal(token)
+	now := time.Now()
+	e := envelope{
+		Token: token,
+		Metadata: Metadata{
+			SavedAt:              now,
+			RefreshTokenIssuedAt: now,
+			Account:              t.Account,
+		},
+	}
+	// keep the issue time of the refresh token if it hasn't changed
+	if current, err := t.load(); err == nil && current.Token.RefreshToken == token.RefreshToken && !current.RefreshTokenIssuedAt.IsZero() {
+		e.RefreshTokenIssuedAt = current.RefreshTokenIssuedAt
+	}
+	bytes, err := json.Marshal(e)
 	if err != nil {
 		return fmt.Errorf("json: %w", err)
 	}
@@ -46,20 +96,47 @@ func (t TokenStore) Save(token *oauth2.Token) error {
 }
 
 func (t TokenStore) Load() (*oauth2.Token, error) {
-	bytes, err := t.Storer.Load()
+	e, err := t.load()
 	if err != nil {
 		return nil, err
 	}
-	var token oauth2.Token
-	if err = json.Unmarshal(bytes, &token); err != nil {
-		return nil, fmt.Errorf("json: %w", err)
+	if t.Account != "" && e.Account != "" && t.Account != e.Account {
+		return nil, ErrAccountMismatch
 	}
-	return &token, nil
+	return e.Token, nil
+}
+
+// Metadata returns the Metadata of the stored token.
+func (t TokenStore) Metadata() (Metadata, error) {
+	e, err := t.load()
+	return e.Metadata, err
 }
 
+// LastSaved returns the time the token was last saved. If the store doesn't contain a token, LastSaved returns the zero time.
 func (t TokenStore) LastSaved() time.Time {
+	metadata, _ := t.Metadata()
+	return metadata.SavedAt
+}
+
+func (t TokenStore) load() (envelope, error) {
+	bytes, err := t.Storer.Load()
+	if err != nil {
+		return envelope{}, err
+	}
+	var e envelope
+	if err = json.Unmarshal(bytes, &e); err != nil {
+		return envelope{}, fmt.Errorf("json: %w", err)
+	}
+	if e.Token != nil {
+		return e, nil
+	}
+	// older versions of TokenStore saved the token without an envelope
+	if err = json.Unmarshal(bytes, &e.Token); err != nil {
+		return envelope{}, fmt.Errorf("json: %w", err)
+	}
 	if fileInfo, err := os.Stat(t.Path); err == nil {
-		return fileInfo.ModTime()
+		e.SavedAt = fileInfo.ModTime()
+		e.RefreshTokenIssuedAt = fileInfo.ModTime()
 	}
-	return time.Time{}
+	return e, nil
 }
@@ -1,6 +1,7 @@
 package oauth2store_test
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
@@ -14,10 +15,11 @@ func TestEncryptedFileStore_Load(t *testing.T) {
 		passphrase string
 		token      *oauth2.Token
 		pass       bool
+		wantErr    error
 	}{
-		{"pass", "good-passphrase", &oauth2.Token{AccessToken: "valid-token"}, true},
-		{"bad passphrase", "bad-passphrase", &oauth2.Token{AccessToken: "valid-token"}, false},
-		{"no store", "good-passphrase", nil, false},
+		{"pass", "good-passphrase", &oauth2.Token{AccessToken: "valid-token"}, true, nil},
+		{"bad passphrase", "bad-passphrase", &oauth2.Token{AccessToken: "valid-token"}, false, oauth2store.ErrBadPassphrase},
+		{"no store", "good-passphrase", nil, false, oauth2store.ErrNoToken},
 	}
 
 	for _, tt := range tests {
@@ -36,6 +38,9 @@ func TestEncryptedFileStore_Load(t *testing.T) {
 			if tt.pass != (err == nil) {
 				t.Errorf("Load() error = %v, wantErr %v", err, tt.pass)
 			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, tt.wantErr)
+			}
 			if err != nil {
 				return
 			}
@@ -48,3 +53,95 @@ func TestEncryptedFileStore_Load(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenStore_Metadata(t *testing.T) {
+	store := oauth2store.TokenStore{Storer: &fakeTokenStore{}, Account: "foo"}
+	if _, err := store.Metadata(); err == nil {
+		t.Fatal("Metadata() on empty store should fail")
+	}
+	if !store.LastSaved().IsZero() {
+		t.Errorf("LastSaved() = %v, want zero", store.LastSaved())
+	}
+
+	if err := store.Save(&oauth2.Token{AccessToken: "access-token-1", RefreshToken: "refresh-token-1"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	first, err := store.Metadata()
+	if err != nil {
+		t.Fatalf("Metadata() failed: %v", err)
+	}
+	if first.SavedAt.IsZero() || first.RefreshTokenIssuedAt.IsZero() || first.Account != "foo" {
+		t.Errorf("unexpected metadata: %+v", first)
+	}
+
+	// same refresh token: issue time doesn't change
+	if err = store.Save(&oauth2.Token{AccessToken: "access-token-2", RefreshToken: "refresh-token-1"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	second, _ := store.Metadata()
+	if !second.RefreshTokenIssuedAt.Equal(first.RefreshTokenIssuedAt) {
+		t.Errorf("RefreshTokenIssuedAt changed: got %v, want %v", second.RefreshTokenIssuedAt, first.RefreshTokenIssuedAt)
+	}
+	if second.SavedAt.Before(first.SavedAt) {
+		t.Errorf("SavedAt went back in time: got %v, want after %v", second.SavedAt, first.SavedAt)
+	}
+
+	// new refresh token: issue time is updated
+	if err = store.Save(&oauth2.Token{AccessToken: "access-token-3", RefreshToken: "refresh-token-2"}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	third, _ := store.Metadata()
+	if third.RefreshTokenIssuedAt.Equal(first.RefreshTokenIssuedAt) {
+		t.Error("RefreshTokenIssuedAt did not change")
+	}
+
+	// token of a different account is not loaded
+	other := oauth2store.TokenStore{Storer: store.Storer, Account: "bar"}
+	if _, err = other.Load(); !errors.Is(err, oauth2store.ErrAccountMismatch) {
+		t.Errorf("Load() error = %v, want %v", err, oauth2store.ErrAccountMismatch)
+	}
+}
+
+func TestTokenStore_Load_Legacy(t *testing.T) {
+	var storer fakeTokenStore
+	_ = storer.Save([]byte(`{"access_token":"legacy-token"}`))
+	store := oauth2store.TokenStore{Storer: &storer}
+
+	token, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if token.AccessToken != "legacy-token" {
+		t.Errorf("got %q, want legacy-token", token.AccessToken)
+	}
+}
+
+func TestTokenStore_Rekey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.enc")
+	store := oauth2store.NewEncryptedFileTokenStore(path, "old-passphrase")
+	if err := store.Save(&oauth2.Token{AccessToken: "valid-token"}); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+
+	if err := store.Rekey("bad-passphrase", "new-passphrase"); !errors.Is(err, oauth2store.ErrBadPassphrase) {
+		t.Fatalf("Rekey() error = %v, want %v", err, oauth2store.ErrBadPassphrase)
+	}
+	if err := store.Rekey("old-passphrase", "new-passphrase"); err != nil {
+		t.Fatalf("Rekey() failed: %v", err)
+	}
+
+	if _, err := store.Load(); !errors.Is(err, oauth2store.ErrBadPassphrase) {
+		t.Errorf("Load() with old passphrase: error = %v, want %v", err, oauth2store.ErrBadPassphrase)
+	}
+	token, err := oauth2store.NewEncryptedFileTokenStore(path, "new-passphrase").Load()
+	if err != nil {
+		t.Fatalf("Load() with new passphrase failed: %v", err)
+	}
+	if token.AccessToken != "valid-token" {
+		t.Errorf("got %q, want valid-token", token.AccessToken)
+	}
+
+	if err = (oauth2store.TokenStore{Storer: &fakeTokenStore{}}).Rekey("old", "new"); err == nil {
+		t.Error("Rekey() on a non-file store should fail")
+	}
+}
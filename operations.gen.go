@@ -0,0 +1,81 @@
+// Code generated by internal/cmd/generate. DO NOT EDIT.
+
+package tado
+
+var operations = []Operation{
+	{ID: "ActivateOpenWindowState", Method: "POST", Path: "/homes/{homeId}/zones/{zoneId}/state/openWindow/activate"},
+	{ID: "CreateZone", Method: "POST", Path: "/homes/{homeId}/zones"},
+	{ID: "DeactivateOpenWindowState", Method: "DELETE", Path: "/homes/{homeId}/zones/{zoneId}/state/openWindow"},
+	{ID: "DeleteMobileDeviceFromHome", Method: "DELETE", Path: "/homes/{homeId}/mobileDevices/{mobileDeviceId}"},
+	{ID: "DeletePresenceLock", Method: "DELETE", Path: "/homes/{homeId}/presenceLock"},
+	{ID: "DeleteZoneOverlay", Method: "DELETE", Path: "/homes/{homeId}/zones/{zoneId}/overlay"},
+	{ID: "DeleteZoneOverlays", Method: "DELETE", Path: "/homes/{homeId}/overlay"},
+	{ID: "GetActiveTimetableType", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/activeTimetable"},
+	{ID: "GetAirComfort", Method: "GET", Path: "/homes/{homeId}/airComfort"},
+	{ID: "GetAwayConfiguration", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/awayConfiguration"},
+	{ID: "GetBoilerInfo", Method: "GET", Path: "/homeByBridge/{bridgeId}/boilerInfo"},
+	{ID: "GetBoilerMaxOutputTemperature", Method: "GET", Path: "/homeByBridge/{bridgeId}/boilerMaxOutputTemperature"},
+	{ID: "GetBoilerWiringInstallationState", Method: "GET", Path: "/homeByBridge/{bridgeId}/boilerWiringInstallationState"},
+	{ID: "GetBridge", Method: "GET", Path: "/bridges/{bridgeId}"},
+	{ID: "GetDefaultZoneOverlay", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/defaultOverlay"},
+	{ID: "GetDevice", Method: "GET", Path: "/devices/{deviceId}"},
+	{ID: "GetDeviceList", Method: "GET", Path: "/homes/{homeId}/deviceList"},
+	{ID: "GetDevices", Method: "GET", Path: "/homes/{homeId}/devices"},
+	{ID: "GetEarlyStart", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/earlyStart"},
+	{ID: "GetFlowTemperatureOptimization", Method: "GET", Path: "/homes/{homeId}/flowTemperatureOptimization"},
+	{ID: "GetHeatingCircuits", Method: "GET", Path: "/homes/{homeId}/heatingCircuits"},
+	{ID: "GetHeatingSystem", Method: "GET", Path: "/homes/{homeId}/heatingSystem"},
+	{ID: "GetHome", Method: "GET", Path: "/homes/{homeId}"},
+	{ID: "GetHomeState", Method: "GET", Path: "/homes/{homeId}/state"},
+	{ID: "GetIncidentDetection", Method: "GET", Path: "/homes/{homeId}/incidentDetection"},
+	{ID: "GetInstallation", Method: "GET", Path: "/homes/{homeId}/installations/{installationId}"},
+	{ID: "GetInstallations", Method: "GET", Path: "/homes/{homeId}/installations"},
+	{ID: "GetInvitations", Method: "GET", Path: "/homes/{homeId}/invitations"},
+	{ID: "GetMe", Method: "GET", Path: "/me"},
+	{ID: "GetMobileDevice", Method: "GET", Path: "/homes/{homeId}/mobileDevices/{mobileDeviceId}"},
+	{ID: "GetMobileDeviceSettings", Method: "GET", Path: "/homes/{homeId}/mobileDevices/{mobileDeviceId}/settings"},
+	{ID: "GetMobileDevices", Method: "GET", Path: "/homes/{homeId}/mobileDevices"},
+	{ID: "GetTemperatureOffset", Method: "GET", Path: "/devices/{deviceId}/temperatureOffset"},
+	{ID: "GetTimetableBlocksByDayType", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/timetables/{timetableTypeId}/blocks/{dayType}"},
+	{ID: "GetUsers", Method: "GET", Path: "/homes/{homeId}/users"},
+	{ID: "GetWeather", Method: "GET", Path: "/homes/{homeId}/weather"},
+	{ID: "GetZoneCapabilities", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/capabilities"},
+	{ID: "GetZoneControl", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/control"},
+	{ID: "GetZoneDayReport", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/dayReport"},
+	{ID: "GetZoneMeasuringDevice", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/measuringDevice"},
+	{ID: "GetZoneOverlay", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/overlay"},
+	{ID: "GetZoneState", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/state"},
+	{ID: "GetZoneStates", Method: "GET", Path: "/homes/{homeId}/zoneStates"},
+	{ID: "GetZoneTimetable", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/timetables/{timetableTypeId}"},
+	{ID: "GetZoneTimetableBlocks", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/timetables/{timetableTypeId}/blocks"},
+	{ID: "GetZoneTimetables", Method: "GET", Path: "/homes/{homeId}/zones/{zoneId}/schedule/timetables"},
+	{ID: "GetZones", Method: "GET", Path: "/homes/{homeId}/zones"},
+	{ID: "IdentifyDevice", Method: "POST", Path: "/devices/{deviceId}/identify"},
+	{ID: "MoveDevice", Method: "POST", Path: "/homes/{homeId}/zones/{zoneId}/devices"},
+	{ID: "ResendInvitation", Method: "POST", Path: "/homes/{homeId}/invitations/{invitationToken}/resend"},
+	{ID: "RevokeInvitation", Method: "DELETE", Path: "/homes/{homeId}/invitations/{invitationToken}"},
+	{ID: "SendInvitation", Method: "POST", Path: "/homes/{homeId}/invitations"},
+	{ID: "SetActiveTimetableType", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/schedule/activeTimetable"},
+	{ID: "SetAwayConfiguration", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/schedule/awayConfiguration"},
+	{ID: "SetAwayRadiusInMeters", Method: "PUT", Path: "/homes/{homeId}/awayRadiusInMeters"},
+	{ID: "SetBoiler", Method: "PUT", Path: "/homes/{homeId}/heatingSystem/boiler"},
+	{ID: "SetBoilerMaxOutputTemperature", Method: "PUT", Path: "/homeByBridge/{bridgeId}/boilerMaxOutputTemperature"},
+	{ID: "SetChildLock", Method: "PUT", Path: "/devices/{deviceId}/childLock"},
+	{ID: "SetDazzle", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/dazzle"},
+	{ID: "SetDefaultZoneOverlay", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/defaultOverlay"},
+	{ID: "SetDetails", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/details"},
+	{ID: "SetEarlyStart", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/earlyStart"},
+	{ID: "SetFlowTemperatureOptimization", Method: "PUT", Path: "/homes/{homeId}/flowTemperatureOptimization"},
+	{ID: "SetHeatingCircuit", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/control/heatingCircuit"},
+	{ID: "SetHomeDetails", Method: "PUT", Path: "/homes/{homeId}/details"},
+	{ID: "SetIncidentDetection", Method: "PUT", Path: "/homes/{homeId}/incidentDetection"},
+	{ID: "SetMobileDeviceSettings", Method: "PUT", Path: "/homes/{homeId}/mobileDevices/{mobileDeviceId}/settings"},
+	{ID: "SetOpenWindowDetection", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/openWindowDetection"},
+	{ID: "SetPresenceLock", Method: "PUT", Path: "/homes/{homeId}/presenceLock"},
+	{ID: "SetTemperatureOffset", Method: "PUT", Path: "/devices/{deviceId}/temperatureOffset"},
+	{ID: "SetTimetableBlocksForDayType", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/schedule/timetables/{timetableTypeId}/blocks/{dayType}"},
+	{ID: "SetUnderfloorHeating", Method: "PUT", Path: "/homes/{homeId}/heatingSystem/underfloorHeating"},
+	{ID: "SetZoneMeasuringDevice", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/measuringDevice"},
+	{ID: "SetZoneOverlay", Method: "PUT", Path: "/homes/{homeId}/zones/{zoneId}/overlay"},
+	{ID: "SetZoneOverlays", Method: "POST", Path: "/homes/{homeId}/overlay"},
+}
@@ -0,0 +1,82 @@
+package tado
+
+import (
+	"net/http"
+	"strings"
+)
+
+// An Operation describes an operation of the Tadoº API, as generated in client.gen.go.
+type Operation struct {
+	// ID is the name of the operation, e.g. "GetZoneState".
+	ID string
+	// Method is the HTTP method of the operation.
+	Method string
+	// Path is the path template of the operation, e.g. "/homes/{homeId}/zones/{zoneId}/state".
+	Path string
+}
+
+// Operations returns all operations of the Tadoº API.
+func Operations() []Operation {
+	return append([]Operation(nil), operations...)
+}
+
+// LookupOperation returns the Operation that created the request, and the values of its path parameters
+// (e.g. "homeId"). If the request doesn't match any operation, ok is false.
+//
+// Since the server URL may contain a path (e.g. ServerURL), the request's path is matched against the end of
+// each operation's path template.
+func LookupOperation(req *http.Request) (op Operation, params map[string]string, ok bool) {
+	segments := splitPath(req.URL.EscapedPath())
+	// when several operations match, use the one with the longest template and the most literal segments
+	var bestLength, bestLiterals int
+	for _, candidate := range operations {
+		if candidate.Method != req.Method {
+			continue
+		}
+		template := splitPath(candidate.Path)
+		if len(template) > len(segments) {
+			continue
+		}
+		literals, matched := matchPath(template, segments[len(segments)-len(template):])
+		if !matched || (ok && (len(template) < bestLength || len(template) == bestLength && literals <= bestLiterals)) {
+			continue
+		}
+		op, bestLength, bestLiterals, ok = candidate, len(template), literals, true
+	}
+	if !ok {
+		return Operation{}, nil, false
+	}
+	template := splitPath(op.Path)
+	params = make(map[string]string)
+	for i, segment := range segments[len(segments)-len(template):] {
+		if name, isParam := pathParam(template[i]); isParam {
+			params[name] = segment
+		}
+	}
+	return op, params, true
+}
+
+// matchPath returns true if the path segments match the template, and the number of literal segments in the template.
+func matchPath(template, segments []string) (literals int, ok bool) {
+	for i := range template {
+		if _, isParam := pathParam(template[i]); isParam {
+			continue
+		}
+		if template[i] != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
@@ -0,0 +1,94 @@
+package tado
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLookupOperation(t *testing.T) {
+	tests := []struct {
+		name       string
+		newRequest func() (*http.Request, error)
+		wantOK     bool
+		wantID     string
+		wantParams map[string]string
+	}{
+		{
+			name:       "no params",
+			newRequest: func() (*http.Request, error) { return NewGetMeRequest(ServerURL) },
+			wantOK:     true,
+			wantID:     "GetMe",
+			wantParams: map[string]string{},
+		},
+		{
+			name:       "home",
+			newRequest: func() (*http.Request, error) { return NewGetZonesRequest(ServerURL, 1) },
+			wantOK:     true,
+			wantID:     "GetZones",
+			wantParams: map[string]string{"homeId": "1"},
+		},
+		{
+			name:       "zone",
+			newRequest: func() (*http.Request, error) { return NewGetZoneStateRequest(ServerURL, 1, 2) },
+			wantOK:     true,
+			wantID:     "GetZoneState",
+			wantParams: map[string]string{"homeId": "1", "zoneId": "2"},
+		},
+		{
+			name: "method",
+			newRequest: func() (*http.Request, error) {
+				return NewSetZoneOverlayRequest(ServerURL, 1, 2, SetZoneOverlayJSONRequestBody{})
+			},
+			wantOK:     true,
+			wantID:     "SetZoneOverlay",
+			wantParams: map[string]string{"homeId": "1", "zoneId": "2"},
+		},
+		{
+			name: "query",
+			newRequest: func() (*http.Request, error) {
+				return NewGetBridgeRequest("http://localhost:8080", "bridge", &GetBridgeParams{AuthKey: "key"})
+			},
+			wantOK:     true,
+			wantID:     "GetBridge",
+			wantParams: map[string]string{"bridgeId": "bridge"},
+		},
+		{
+			name:       "unknown",
+			newRequest: func() (*http.Request, error) { return http.NewRequest(http.MethodGet, ServerURL+"/foo", nil) },
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.newRequest()
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			op, params, ok := LookupOperation(req)
+			if ok != tt.wantOK {
+				t.Fatalf("got ok=%v, want %v", ok, tt.wantOK)
+			}
+			if op.ID != tt.wantID {
+				t.Errorf("got operation %q, want %q", op.ID, tt.wantID)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("got params %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	ids := make(map[string]struct{})
+	for _, op := range Operations() {
+		if _, ok := ids[op.ID]; ok {
+			t.Errorf("duplicate operation %q", op.ID)
+		}
+		ids[op.ID] = struct{}{}
+	}
+	if len(ids) == 0 {
+		t.Error("no operations")
+	}
+}
@@ -0,0 +1,66 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrReauthenticationRequired indicates that the refresh token was rejected (e.g. because it expired or was revoked)
+// and the user needs to log in again.
+var ErrReauthenticationRequired = errors.New("reauthentication required")
+
+// A ReauthHandler returns a new token when the refresh token is rejected, typically by performing a new
+// device code authentication flow. DeviceAuthenticator's Token method can be used as a ReauthHandler.
+type ReauthHandler func(ctx context.Context) (*oauth2.Token, error)
+
+// WithReauthHandler sets the handler called when the refresh token is rejected. While the handler runs,
+// all API calls wait for the new token.
+//
+// Without a ReauthHandler, API calls fail with ErrReauthenticationRequired once the refresh token is rejected.
+func WithReauthHandler(handler ReauthHandler) OAuth2ClientOption {
+	return func(o *oauth2ClientOptions) {
+		o.reauthHandler = handler
+	}
+}
+
+var _ oauth2.TokenSource = &reauthTokenSource{}
+
+// reauthTokenSource is an oauth2.TokenSource that obtains a new token from its handler when the refresh token is rejected.
+type reauthTokenSource struct {
+	ctx     context.Context
+	config  *oauth2.Config
+	source  oauth2.TokenSource
+	handler ReauthHandler
+	lock    sync.Mutex
+}
+
+func newReauthTokenSource(ctx context.Context, config *oauth2.Config, token *oauth2.Token, handler ReauthHandler) *reauthTokenSource {
+	return &reauthTokenSource{
+		ctx:     ctx,
+		config:  config,
+		source:  config.TokenSource(ctx, token),
+		handler: handler,
+	}
+}
+
+func (r *reauthTokenSource) Token() (*oauth2.Token, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	token, err := r.source.Token()
+	if err == nil || retrieveErrorCode(err) != "invalid_grant" {
+		return token, err
+	}
+	if r.handler == nil {
+		return nil, fmt.Errorf("%w: %w", ErrReauthenticationRequired, err)
+	}
+	if token, err = r.handler(r.ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReauthenticationRequired, err)
+	}
+	r.source = r.config.TokenSource(r.ctx, token)
+	return token, nil
+}
@@ -0,0 +1,74 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestReauthTokenSource_Token(t *testing.T) {
+	tests := []struct {
+		name    string
+		revoke  bool
+		handler ReauthHandler
+		wantErr error
+		want    string
+	}{
+		{
+			name: "valid refresh token",
+			want: "access-token",
+		},
+		{
+			name:    "revoked refresh token",
+			revoke:  true,
+			wantErr: ErrReauthenticationRequired,
+		},
+		{
+			name:   "revoked refresh token with handler",
+			revoke: true,
+			handler: func(_ context.Context) (*oauth2.Token, error) {
+				return &oauth2.Token{AccessToken: "new-token", Expiry: time.Now().Add(time.Hour)}, nil
+			},
+			want: "new-token",
+		},
+		{
+			name:   "handler fails",
+			revoke: true,
+			handler: func(_ context.Context) (*oauth2.Token, error) {
+				return nil, ErrDeviceAuthDenied
+			},
+			wantErr: ErrDeviceAuthDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newFakeAuthServer()
+			defer auth.Close()
+			auth.revoke.Store(tt.revoke)
+
+			cfg := Config
+			cfg.Endpoint = auth.endpoint()
+			expired := &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh-token", Expiry: time.Now().Add(-time.Hour)}
+			ts := newReauthTokenSource(t.Context(), &cfg, expired, tt.handler)
+
+			token, err := ts.Token()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Token() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if token.AccessToken != tt.want {
+				t.Errorf("got %q, want %q", token.AccessToken, tt.want)
+			}
+			// subsequent calls reuse the new token
+			if token, err = ts.Token(); err != nil || token.AccessToken != tt.want {
+				t.Errorf("got (%v, %v), want %q", token, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Code generated by internal/cmd/generate. DO NOT EDIT.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/clambin/tado/v2"
+)
+
+// GetBridge Retrieve Internet Bridge details.
+func (c *Client) GetBridge(ctx context.Context, bridgeId tado.BridgeId, params *tado.GetBridgeParams, reqEditors ...tado.RequestEditorFn) (tado.Bridge, error) {
+	return Result(c.client.GetBridgeWithResponse(ctx, bridgeId, params, reqEditors...))
+}
+
+// GetDevice Get a single tado device.
+func (c *Client) GetDevice(ctx context.Context, deviceId tado.DeviceId, reqEditors ...tado.RequestEditorFn) (tado.Device, error) {
+	return Result(c.client.GetDeviceWithResponse(ctx, deviceId, reqEditors...))
+}
+
+// SetChildLock Enable or disable the child lock for this device.
+func (c *Client) SetChildLock(ctx context.Context, deviceId tado.DeviceId, body tado.SetChildLockJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetChildLockWithResponse(ctx, deviceId, body, reqEditors...))
+}
+
+// IdentifyDevice Instruct the device to say 'hi' on its display.
+func (c *Client) IdentifyDevice(ctx context.Context, deviceId tado.DeviceId, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.IdentifyDeviceWithResponse(ctx, deviceId, reqEditors...))
+}
+
+// GetTemperatureOffset Get the temperature offset for the given device.
+func (c *Client) GetTemperatureOffset(ctx context.Context, deviceId tado.DeviceId, reqEditors ...tado.RequestEditorFn) (tado.Temperature, error) {
+	return Result(c.client.GetTemperatureOffsetWithResponse(ctx, deviceId, reqEditors...))
+}
+
+// SetTemperatureOffset Set the temperature offset for the given device (there is no DELETE for this resource).
+func (c *Client) SetTemperatureOffset(ctx context.Context, deviceId tado.DeviceId, body tado.SetTemperatureOffsetJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.Temperature, error) {
+	return Result(c.client.SetTemperatureOffsetWithResponse(ctx, deviceId, body, reqEditors...))
+}
+
+// GetBoilerInfo Retrieve information about this home's boiler.
+func (c *Client) GetBoilerInfo(ctx context.Context, bridgeId tado.BridgeId, params *tado.GetBoilerInfoParams, reqEditors ...tado.RequestEditorFn) (tado.Boiler2, error) {
+	return Result(c.client.GetBoilerInfoWithResponse(ctx, bridgeId, params, reqEditors...))
+}
+
+// GetBoilerMaxOutputTemperature Retrieve the boiler's maximum output temperature.
+func (c *Client) GetBoilerMaxOutputTemperature(ctx context.Context, bridgeId tado.BridgeId, params *tado.GetBoilerMaxOutputTemperatureParams, reqEditors ...tado.RequestEditorFn) (tado.BoilerMaxOutputTemperature, error) {
+	return Result(c.client.GetBoilerMaxOutputTemperatureWithResponse(ctx, bridgeId, params, reqEditors...))
+}
+
+// SetBoilerMaxOutputTemperature Set the boiler's maximum output temperature.
+func (c *Client) SetBoilerMaxOutputTemperature(ctx context.Context, bridgeId tado.BridgeId, params *tado.SetBoilerMaxOutputTemperatureParams, body tado.SetBoilerMaxOutputTemperatureJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetBoilerMaxOutputTemperatureWithResponse(ctx, bridgeId, params, body, reqEditors...))
+}
+
+// GetBoilerWiringInstallationState Retrieves information about the connection between your boiler  and the wired tado device which controls your boiler.
+func (c *Client) GetBoilerWiringInstallationState(ctx context.Context, bridgeId tado.BridgeId, params *tado.GetBoilerWiringInstallationStateParams, reqEditors ...tado.RequestEditorFn) (tado.BoilerWiringInstallationState, error) {
+	return Result(c.client.GetBoilerWiringInstallationStateWithResponse(ctx, bridgeId, params, reqEditors...))
+}
+
+// GetHome Get full details of a single home.
+func (c *Client) GetHome(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.Home, error) {
+	return Result(c.client.GetHomeWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetAirComfort Get humidity and temperature indicators for all zones (rooms) in this home
+func (c *Client) GetAirComfort(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.AirComfort, error) {
+	return Result(c.client.GetAirComfortWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SetAwayRadiusInMeters Set the geo-tracking distance.
+func (c *Client) SetAwayRadiusInMeters(ctx context.Context, homeId tado.HomeId, body tado.SetAwayRadiusInMetersJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetAwayRadiusInMetersWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// SetHomeDetails Set home details for this home.
+func (c *Client) SetHomeDetails(ctx context.Context, homeId tado.HomeId, body tado.SetHomeDetailsJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetHomeDetailsWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// GetDeviceList Get all tado devices associated with the provided homeId, together with the zone (a.k.a. room) they are in.
+func (c *Client) GetDeviceList(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.DeviceList, error) {
+	return Result(c.client.GetDeviceListWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetDevices Get all tado devices associated with the provided homeId.
+func (c *Client) GetDevices(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.Device, error) {
+	return Result(c.client.GetDevicesWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetFlowTemperatureOptimization Get the boiler's water temperature specifics.
+func (c *Client) GetFlowTemperatureOptimization(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.FlowTemperatureOptimization, error) {
+	return Result(c.client.GetFlowTemperatureOptimizationWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SetFlowTemperatureOptimization Set the boiler's water temperature specifics.
+func (c *Client) SetFlowTemperatureOptimization(ctx context.Context, homeId tado.HomeId, body tado.SetFlowTemperatureOptimizationJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetFlowTemperatureOptimizationWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// GetHeatingCircuits Get information about the heating circuits of this home. [ + tado X]
+func (c *Client) GetHeatingCircuits(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.HeatingCircuit, error) {
+	return Result(c.client.GetHeatingCircuitsWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetHeatingSystem Get information about the presence of various heating systems in this home
+func (c *Client) GetHeatingSystem(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.HeatingSystem, error) {
+	return Result(c.client.GetHeatingSystemWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SetBoiler Inform tado about the presence of a boiler in this home
+func (c *Client) SetBoiler(ctx context.Context, homeId tado.HomeId, body tado.SetBoilerJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetBoilerWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// SetUnderfloorHeating Inform tado about the presence of underfloor heating in this home
+func (c *Client) SetUnderfloorHeating(ctx context.Context, homeId tado.HomeId, body tado.SetUnderfloorHeatingJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetUnderfloorHeatingWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// GetIncidentDetection Get the value of the incidentDetection setting for this home.
+func (c *Client) GetIncidentDetection(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.IncidentDetection, error) {
+	return Result(c.client.GetIncidentDetectionWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SetIncidentDetection Enable or disable incident detection setting for this home.
+func (c *Client) SetIncidentDetection(ctx context.Context, homeId tado.HomeId, body tado.SetIncidentDetectionJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetIncidentDetectionWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// GetInstallations Response only contains AC installations; will be empty when there are no ACs in the home.
+func (c *Client) GetInstallations(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.Installation, error) {
+	return Result(c.client.GetInstallationsWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetInstallation Returns the specified installation.
+func (c *Client) GetInstallation(ctx context.Context, homeId tado.HomeId, installationId tado.InstallationId, reqEditors ...tado.RequestEditorFn) (tado.Installation, error) {
+	return Result(c.client.GetInstallationWithResponse(ctx, homeId, installationId, reqEditors...))
+}
+
+// GetInvitations Get all pending invitations.
+func (c *Client) GetInvitations(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.Invitation, error) {
+	return Result(c.client.GetInvitationsWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SendInvitation Send an invitation
+func (c *Client) SendInvitation(ctx context.Context, homeId tado.HomeId, body tado.SendInvitationJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.Invitation, error) {
+	return Result(c.client.SendInvitationWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// RevokeInvitation Revoke an invitation
+func (c *Client) RevokeInvitation(ctx context.Context, homeId tado.HomeId, invitationToken tado.InvitationToken, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.RevokeInvitationWithResponse(ctx, homeId, invitationToken, reqEditors...))
+}
+
+// ResendInvitation Resend an invitation
+func (c *Client) ResendInvitation(ctx context.Context, homeId tado.HomeId, invitationToken tado.InvitationToken, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.ResendInvitationWithResponse(ctx, homeId, invitationToken, reqEditors...))
+}
+
+// GetMobileDevices Get all mobile devices associated with the provided homeId
+func (c *Client) GetMobileDevices(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.MobileDevice, error) {
+	return Result(c.client.GetMobileDevicesWithResponse(ctx, homeId, reqEditors...))
+}
+
+// DeleteMobileDeviceFromHome Remove the relationship between a mobile device and a home
+func (c *Client) DeleteMobileDeviceFromHome(ctx context.Context, homeId tado.HomeId, mobileDeviceId int64, params *tado.DeleteMobileDeviceFromHomeParams, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.DeleteMobileDeviceFromHomeWithResponse(ctx, homeId, mobileDeviceId, params, reqEditors...))
+}
+
+// GetMobileDevice Get a specific mobile device associated with the provided homeId
+func (c *Client) GetMobileDevice(ctx context.Context, homeId tado.HomeId, mobileDeviceId tado.MobileDeviceId, reqEditors ...tado.RequestEditorFn) (tado.MobileDevice, error) {
+	return Result(c.client.GetMobileDeviceWithResponse(ctx, homeId, mobileDeviceId, reqEditors...))
+}
+
+// GetMobileDeviceSettings Get the settings for a specific mobile device which is associated with the given home
+func (c *Client) GetMobileDeviceSettings(ctx context.Context, homeId tado.HomeId, mobileDeviceId tado.MobileDeviceId, reqEditors ...tado.RequestEditorFn) (tado.MobileDeviceSettings, error) {
+	return Result(c.client.GetMobileDeviceSettingsWithResponse(ctx, homeId, mobileDeviceId, reqEditors...))
+}
+
+// SetMobileDeviceSettings Update the settings for a specific mobile device which is associated with the given home
+func (c *Client) SetMobileDeviceSettings(ctx context.Context, homeId tado.HomeId, mobileDeviceId int64, body tado.SetMobileDeviceSettingsJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.MobileDeviceSettings, error) {
+	return Result(c.client.SetMobileDeviceSettingsWithResponse(ctx, homeId, mobileDeviceId, body, reqEditors...))
+}
+
+// DeleteZoneOverlays Remove the zone overlay (manual override of the configured schedule) of multiple rooms (a.k.a. zones) with a single API method
+func (c *Client) DeleteZoneOverlays(ctx context.Context, homeId tado.HomeId, params *tado.DeleteZoneOverlaysParams, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.DeleteZoneOverlaysWithResponse(ctx, homeId, params, reqEditors...))
+}
+
+// SetZoneOverlays Configure the zone overlay (manual override of the configured schedule) of multiple rooms (a.k.a. zones) with a single API method
+func (c *Client) SetZoneOverlays(ctx context.Context, homeId tado.HomeId, body tado.SetZoneOverlaysJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetZoneOverlaysWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// DeletePresenceLock Remove the manually set home status.
+func (c *Client) DeletePresenceLock(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.DeletePresenceLockWithResponse(ctx, homeId, reqEditors...))
+}
+
+// SetPresenceLock Manually specify whether someone at home or not.
+func (c *Client) SetPresenceLock(ctx context.Context, homeId tado.HomeId, body tado.SetPresenceLockJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetPresenceLockWithResponse(ctx, homeId, body, reqEditors...))
+}
+
+// GetHomeState Get information about the presence state of the home.
+func (c *Client) GetHomeState(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.HomeState, error) {
+	return Result(c.client.GetHomeStateWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetUsers Get all users associated with the provided homeId.
+func (c *Client) GetUsers(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.User, error) {
+	return Result(c.client.GetUsersWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetWeather Get the current weather for the given home.
+func (c *Client) GetWeather(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.Weather, error) {
+	return Result(c.client.GetWeatherWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetZoneStates Get zone state details of every zone (a.k.a. room) in the specified home.
+func (c *Client) GetZoneStates(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) (tado.ZoneStates, error) {
+	return Result(c.client.GetZoneStatesWithResponse(ctx, homeId, reqEditors...))
+}
+
+// GetZones Get all zones (a.k.a. rooms) associated with the provided homeId.
+func (c *Client) GetZones(ctx context.Context, homeId tado.HomeId, reqEditors ...tado.RequestEditorFn) ([]tado.Zone, error) {
+	return Result(c.client.GetZonesWithResponse(ctx, homeId, reqEditors...))
+}
+
+// CreateZone Create a new zone (a.k.a. room) and move devices to it.
+func (c *Client) CreateZone(ctx context.Context, homeId tado.HomeId, params *tado.CreateZoneParams, body tado.CreateZoneJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.CreateZoneWithResponse(ctx, homeId, params, body, reqEditors...))
+}
+
+// GetZoneCapabilities Get the climate control capabilities of this zone.
+func (c *Client) GetZoneCapabilities(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.ZoneCapabilities, error) {
+	return Result(c.client.GetZoneCapabilitiesWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// GetZoneControl Returns the heating circuit the zone (a.k.a. room) belongs to, and the tado devices within the zone  grouped by their duty. [ NO tado X]
+func (c *Client) GetZoneControl(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.ZoneControl, error) {
+	return Result(c.client.GetZoneControlWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetHeatingCircuit Assign the zone to a specific heating circuit or make it independent of a heating circuit. [ NO tado X]
+func (c *Client) SetHeatingCircuit(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetHeatingCircuitJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.ZoneControl, error) {
+	return Result(c.client.SetHeatingCircuitWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetZoneDayReport Get historic information for a particular day for a particular zone (a.k.a. room).
+func (c *Client) GetZoneDayReport(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, params *tado.GetZoneDayReportParams, reqEditors ...tado.RequestEditorFn) (tado.DayReport, error) {
+	return Result(c.client.GetZoneDayReportWithResponse(ctx, homeId, zoneId, params, reqEditors...))
+}
+
+// SetDazzle Enable or disable the dazzle mode of this zone.
+func (c *Client) SetDazzle(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetDazzleJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetDazzleWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetDefaultZoneOverlay Get the ZoneOverlay.termination.typeSkillBasedApp value to use when a user sets (and thus overrides)  the target temperature for a zone via a tado thermostat device.
+func (c *Client) GetDefaultZoneOverlay(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.DefaultZoneOverlay, error) {
+	return Result(c.client.GetDefaultZoneOverlayWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetDefaultZoneOverlay Set the ZoneOverlay.termination.typeSkillBasedApp value to use when a user sets (and thus overrides)  the target temperature for a zone via a tado thermostat device.
+func (c *Client) SetDefaultZoneOverlay(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetDefaultZoneOverlayJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.DefaultZoneOverlay, error) {
+	return Result(c.client.SetDefaultZoneOverlayWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// SetDetails Change the name of the zone
+func (c *Client) SetDetails(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetDetailsJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.Zone, error) {
+	return Result(c.client.SetDetailsWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// MoveDevice Move a device to another pre-existing zone (a.k.a. room)
+func (c *Client) MoveDevice(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, params *tado.MoveDeviceParams, body tado.MoveDeviceJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.MoveDeviceWithResponse(ctx, homeId, zoneId, params, body, reqEditors...))
+}
+
+// GetEarlyStart Control whether Tado makes sure a set temperature is reached at the start of a block
+func (c *Client) GetEarlyStart(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.EarlyStart, error) {
+	return Result(c.client.GetEarlyStartWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetEarlyStart Control whether Tado makes sure a set temperature is reached at the start of a block
+func (c *Client) SetEarlyStart(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetEarlyStartJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.EarlyStart, error) {
+	return Result(c.client.SetEarlyStartWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetZoneMeasuringDevice Returns the tado device which measures temperature and humidity in the given zone (a.k.a. room).
+func (c *Client) GetZoneMeasuringDevice(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.Device, error) {
+	return Result(c.client.GetZoneMeasuringDeviceWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetZoneMeasuringDevice Set the tado device which should measure temperature and humidity in the given zone (a.k.a. room).
+func (c *Client) SetZoneMeasuringDevice(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetZoneMeasuringDeviceJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.Device, error) {
+	return Result(c.client.SetZoneMeasuringDeviceWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// SetOpenWindowDetection Set the open window detection settings for this zone
+func (c *Client) SetOpenWindowDetection(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetOpenWindowDetectionJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetOpenWindowDetectionWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// DeleteZoneOverlay Remove the overlay (manual override of the configured temperature schedule) for the given zone (a.k.a. room)
+func (c *Client) DeleteZoneOverlay(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.DeleteZoneOverlayWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// GetZoneOverlay Get the overlay (manual override of the configured temperature schedule) of the given zone (a.k.a. room)
+func (c *Client) GetZoneOverlay(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.ZoneOverlay, error) {
+	return Result(c.client.GetZoneOverlayWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetZoneOverlay Set the overlay (manual override of the configured temperature schedule) for the given zone (a.k.a. room)
+func (c *Client) SetZoneOverlay(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetZoneOverlayJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.ZoneOverlay, error) {
+	return Result(c.client.SetZoneOverlayWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetActiveTimetableType Get the active timetable type for the given zone (a.k.a. room)
+func (c *Client) GetActiveTimetableType(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.TimetableType, error) {
+	return Result(c.client.GetActiveTimetableTypeWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetActiveTimetableType Set the active timetable type for the given zone (a.k.a. room)
+func (c *Client) SetActiveTimetableType(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetActiveTimetableTypeJSONRequestBody, reqEditors ...tado.RequestEditorFn) (tado.TimetableType, error) {
+	return Result(c.client.SetActiveTimetableTypeWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetAwayConfiguration Get the (temperature) settings to use for the given zone (a.k.a. room) when the home state is AWAY.
+func (c *Client) GetAwayConfiguration(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.ZoneAwayConfiguration, error) {
+	return Result(c.client.GetAwayConfigurationWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// SetAwayConfiguration Set the (temperature) settings to use for the given zone (a.k.a. room) when the home state is AWAY.
+func (c *Client) SetAwayConfiguration(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, body tado.SetAwayConfigurationJSONRequestBody, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.SetAwayConfigurationWithResponse(ctx, homeId, zoneId, body, reqEditors...))
+}
+
+// GetZoneTimetables Get the available timetable types
+func (c *Client) GetZoneTimetables(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) ([]tado.TimetableType, error) {
+	return Result(c.client.GetZoneTimetablesWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// GetZoneTimetable Get the timetable type for the given timetable type ID
+func (c *Client) GetZoneTimetable(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, timetableTypeId int, reqEditors ...tado.RequestEditorFn) (tado.TimetableType, error) {
+	return Result(c.client.GetZoneTimetableWithResponse(ctx, homeId, zoneId, timetableTypeId, reqEditors...))
+}
+
+// GetZoneTimetableBlocks Get the user defined timetable blocks for the given zoneId and given timetableTypeId.
+func (c *Client) GetZoneTimetableBlocks(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, timetableTypeId tado.TimetableTypeId, reqEditors ...tado.RequestEditorFn) ([]tado.TimetableBlock, error) {
+	return Result(c.client.GetZoneTimetableBlocksWithResponse(ctx, homeId, zoneId, timetableTypeId, reqEditors...))
+}
+
+// GetTimetableBlocksByDayType Get the user defined timetable blocks for the given zoneId, timetableTypeId and dayType
+func (c *Client) GetTimetableBlocksByDayType(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, timetableTypeId tado.TimetableTypeId, dayType tado.DayType, reqEditors ...tado.RequestEditorFn) ([]tado.TimetableBlock, error) {
+	return Result(c.client.GetTimetableBlocksByDayTypeWithResponse(ctx, homeId, zoneId, timetableTypeId, dayType, reqEditors...))
+}
+
+// SetTimetableBlocksForDayType Update the timetable (containing all blocks!) for the given zoneId, timetableTypeId and dayType
+func (c *Client) SetTimetableBlocksForDayType(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, timetableTypeId tado.TimetableTypeId, dayType tado.DayType, body tado.SetTimetableBlocksForDayTypeJSONRequestBody, reqEditors ...tado.RequestEditorFn) ([]tado.TimetableBlock, error) {
+	return Result(c.client.SetTimetableBlocksForDayTypeWithResponse(ctx, homeId, zoneId, timetableTypeId, dayType, body, reqEditors...))
+}
+
+// GetZoneState Get the current state details of a zone (a.k.a. room).
+func (c *Client) GetZoneState(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) (tado.ZoneState, error) {
+	return Result(c.client.GetZoneStateWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// DeactivateOpenWindowState To be determined what this operation exactly does.
+func (c *Client) DeactivateOpenWindowState(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.DeactivateOpenWindowStateWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// ActivateOpenWindowState To be determined what this operation exactly does.
+func (c *Client) ActivateOpenWindowState(ctx context.Context, homeId tado.HomeId, zoneId tado.ZoneId, reqEditors ...tado.RequestEditorFn) error {
+	return Check(c.client.ActivateOpenWindowStateWithResponse(ctx, homeId, zoneId, reqEditors...))
+}
+
+// GetMe Returns information about the authenticated user, (summary) information of their home(s) and mobile devices.
+func (c *Client) GetMe(ctx context.Context, reqEditors ...tado.RequestEditorFn) (tado.User, error) {
+	return Result(c.client.GetMeWithResponse(ctx, reqEditors...))
+}
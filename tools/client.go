@@ -0,0 +1,17 @@
+package tools
+
+import "github.com/clambin/tado/v2"
+
+// Client wraps a tado.ClientWithResponsesInterface. Its methods return the payload of the response and an error,
+// so that applications don't need to check the status code of each response. If the server returns an error,
+// the error is an *APIError (see Result and Check).
+//
+// Client's methods are generated from tado.ClientWithResponsesInterface by go generate.
+type Client struct {
+	client tado.ClientWithResponsesInterface
+}
+
+// NewClient returns a Client that performs its calls with client.
+func NewClient(client tado.ClientWithResponsesInterface) *Client {
+	return &Client{client: client}
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestClient(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/me":
+			_, _ = w.Write([]byte(`{"name":"foo"}`))
+		case "/homes/1/presenceLock":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"notFound","title":"not found"}]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	c, err := tado.NewClientWithResponses(s.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	client := NewClient(c)
+
+	me, err := client.GetMe(t.Context())
+	if err != nil {
+		t.Fatalf("GetMe failed: %v", err)
+	}
+	if me.Name == nil || *me.Name != "foo" {
+		t.Errorf("got name %v, want foo", me.Name)
+	}
+
+	if err = client.SetPresenceLock(t.Context(), 1, tado.SetPresenceLockJSONRequestBody{HomePresence: VarP(tado.HOME)}); err != nil {
+		t.Errorf("SetPresenceLock failed: %v", err)
+	}
+
+	if _, err = client.GetZones(t.Context(), 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err %v, want %v", err, ErrNotFound)
+	}
+}
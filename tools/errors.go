@@ -5,8 +5,71 @@ import (
 	"fmt"
 	"github.com/clambin/tado/v2"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// Sentinel errors for common HTTP status codes. Use errors.Is to check an error returned by HandleErrors:
+//
+//	if errors.Is(err, tools.ErrUnauthorized) {
+//		// log in again
+//	}
+var (
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrNotFound      = errors.New("not found")
+	ErrUnprocessable = errors.New("unprocessable entity")
+	ErrRateLimited   = errors.New("rate limited")
+)
+
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusUnprocessableEntity: ErrUnprocessable,
+	http.StatusTooManyRequests:     ErrRateLimited,
+}
+
+// APIError is the error returned by HandleErrors. It contains the errors returned by the Tadoº API.
+// Use errors.As to access them:
+//
+//	var apiErr *tools.APIError
+//	if errors.As(err, &apiErr) {
+//		for _, e := range apiErr.Errors422 {
+//			// ...
+//		}
+//	}
+type APIError struct {
+	// RetryAfter is the time when the request may be retried, as reported by the Retry-After header. It is zero if the server didn't report it.
+	RetryAfter time.Time
+	err        error
+	// Operation is the ID of the generated operation that created the request (e.g. "GetMe"). It is empty if the operation is not known.
+	Operation string
+	// Status is the HTTP status of the response.
+	Status string
+	// Errors contains the errors of an ErrorResponse.
+	Errors []tado.Error
+	// Errors422 contains the errors of an ErrorResponse422.
+	Errors422 []tado.Error422
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error: the errors returned by the Tadoº API, or the Go error passed to HandleErrors.
+func (e *APIError) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
+
+// Is reports whether the error matches one of the sentinel errors (e.g. ErrUnauthorized) for its status code.
+func (e *APIError) Is(target error) bool {
+	err, ok := statusErrors[e.StatusCode]
+	return ok && err == target
+}
+
 // HandleErrors converts an HTTP error received from the server to a generic Go error.
 // resp should be the http.Response received, and tadoErrors a map of received Error objects
 // by HTTP StatusCode, for example:
@@ -20,29 +83,54 @@ import (
 //			http.StatusUnauthorized: me.JSON401,
 //		})
 //	}
+//
+// The returned error is an *APIError, which can be checked with errors.Is against the sentinel errors (e.g. ErrUnauthorized).
 func HandleErrors(resp *http.Response, tadoErrors map[int]any) error {
-	tadoError, ok := tadoErrors[resp.StatusCode]
-	if !ok {
-		return fmt.Errorf("http: %d - %s", resp.StatusCode, resp.Status)
+	apiErr := APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
 	}
-	switch e := tadoError.(type) {
+	if resp.Request != nil {
+		if op, _, ok := tado.LookupOperation(resp.Request); ok {
+			apiErr.Operation = op.ID
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Now().Add(time.Duration(seconds) * time.Second)
+	} else if retryAfter, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = retryAfter
+	}
+
+	switch e := tadoErrors[resp.StatusCode].(type) {
+	case nil:
 	case *tado.ErrorResponse:
-		return fmt.Errorf("tado: %d - %w", resp.StatusCode, Errors(e))
+		if err := Errors(e); err != nil {
+			apiErr.Errors = *e.Errors
+			apiErr.err = fmt.Errorf("tado: %d - %w", resp.StatusCode, err)
+		}
 	case *tado.ErrorResponse422:
-		return fmt.Errorf("tado: %d - %w", resp.StatusCode, Errors422(e))
+		if err := Errors422(e); err != nil {
+			apiErr.Errors422 = *e.Errors
+			apiErr.err = fmt.Errorf("tado: %d - %w", resp.StatusCode, err)
+		}
 	case error:
-		return fmt.Errorf("tado: %d - %w", resp.StatusCode, e)
+		apiErr.err = fmt.Errorf("tado: %d - %w", resp.StatusCode, e)
 	default:
-		return fmt.Errorf("tado: %d - %s (%T)", resp.StatusCode, resp.Status, tadoError)
+		apiErr.err = fmt.Errorf("tado: %d - %s (%T)", resp.StatusCode, resp.Status, e)
+	}
+	// no (valid) tado error received for this status code
+	if apiErr.err == nil {
+		apiErr.err = fmt.Errorf("http: %d - %s", resp.StatusCode, resp.Status)
 	}
+	return &apiErr
 }
 
 // Errors converts the errors in ErrorResponse to a Go error
 func Errors(errs *tado.ErrorResponse) error {
 	var err error
-	if errs != nil {
+	if errs != nil && errs.Errors != nil {
 		for _, e := range *errs.Errors {
-			err = errors.Join(err, fmt.Errorf("%s - %s", *e.Code, *e.Title))
+			err = errors.Join(err, fmt.Errorf("%s - %s", deref(e.Code), deref(e.Title)))
 		}
 	}
 	return err
@@ -51,16 +139,24 @@ func Errors(errs *tado.ErrorResponse) error {
 // Errors422 converts the errors in ErrorResponse422 to a Go error
 func Errors422(errs *tado.ErrorResponse422) error {
 	var err error
-	if errs != nil {
+	if errs != nil && errs.Errors != nil {
 		for _, e := range *errs.Errors {
 			var e1 error
 			if e.ZoneType == nil {
-				e1 = fmt.Errorf("%s - %s", *e.Code, *e.Title)
+				e1 = fmt.Errorf("%s - %s", deref(e.Code), deref(e.Title))
 			} else {
-				err = fmt.Errorf("%s - %s (zoneType: %s)", *e.Code, *e.Title, *e.ZoneType)
+				e1 = fmt.Errorf("%s - %s (zoneType: %s)", deref(e.Code), deref(e.Title), *e.ZoneType)
 			}
 			err = errors.Join(err, e1)
 		}
 	}
 	return err
 }
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
@@ -70,6 +70,81 @@ func TestHandleErrors(t *testing.T) {
 	}
 }
 
+func TestHandleErrors_APIError(t *testing.T) {
+	req, _ := tado.NewGetZoneStateRequest(tado.ServerURL, 1, 2)
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		tadoErrs   map[int]any
+		want       error
+		wantErrors int
+	}{
+		{
+			name: "unauthorized",
+			resp: &http.Response{StatusCode: http.StatusUnauthorized, Request: req},
+			tadoErrs: map[int]any{
+				http.StatusUnauthorized: &tado.ErrorResponse{Errors: &[]tado.Error{{Code: VarP("unauthorized"), Title: VarP("bad token")}}},
+			},
+			want:       ErrUnauthorized,
+			wantErrors: 1,
+		},
+		{
+			name: "forbidden",
+			resp: &http.Response{StatusCode: http.StatusForbidden, Request: req},
+			want: ErrForbidden,
+		},
+		{
+			name: "not found",
+			resp: &http.Response{StatusCode: http.StatusNotFound, Request: req},
+			tadoErrs: map[int]any{
+				http.StatusNotFound: (*tado.ErrorResponse)(nil),
+			},
+			want: ErrNotFound,
+		},
+		{
+			name: "unprocessable",
+			resp: &http.Response{StatusCode: http.StatusUnprocessableEntity, Request: req},
+			tadoErrs: map[int]any{
+				http.StatusUnprocessableEntity: &tado.ErrorResponse422{Errors: &[]tado.Error422{{Code: VarP("invalid"), ZoneType: VarP(tado.HEATING)}}},
+			},
+			want:       ErrUnprocessable,
+			wantErrors: 1,
+		},
+		{
+			name: "rate limited",
+			resp: &http.Response{StatusCode: http.StatusTooManyRequests, Request: req, Header: http.Header{"Retry-After": []string{"60"}}},
+			want: ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HandleErrors(tt.resp, tt.tadoErrs)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+			for _, other := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrUnprocessable, ErrRateLimited} {
+				if other != tt.want && errors.Is(err, other) {
+					t.Errorf("error should not match %v", other)
+				}
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %T, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.resp.StatusCode || apiErr.Operation != "GetZoneState" {
+				t.Errorf("unexpected APIError: %+v", apiErr)
+			}
+			if got := len(apiErr.Errors) + len(apiErr.Errors422); got != tt.wantErrors {
+				t.Errorf("got %d errors, want %d", got, tt.wantErrors)
+			}
+			if (tt.resp.Header.Get("Retry-After") != "") == apiErr.RetryAfter.IsZero() {
+				t.Errorf("unexpected RetryAfter: %v", apiErr.RetryAfter)
+			}
+		})
+	}
+}
+
 func TestErrors(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -151,6 +226,15 @@ func TestErrors422(t *testing.T) {
 			wantErr: true,
 			want:    "foo - error foo (zoneType: HEATING)",
 		},
+		{
+			name: "multiple errors with zoneType",
+			err: &tado.ErrorResponse422{Errors: &[]tado.Error422{
+				{Code: VarP("foo"), Title: VarP("error foo")},
+				{Code: VarP("bar"), Title: VarP("error bar"), ZoneType: VarP(tado.HOTWATER)},
+			}},
+			wantErr: true,
+			want:    "foo - error foo\nbar - error bar (zoneType: HOT_WATER)",
+		},
 	}
 
 	for _, tt := range tests {
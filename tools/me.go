@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/clambin/tado/v2"
-	"net/http"
 )
 
 type TadoClient interface {
@@ -13,14 +12,12 @@ type TadoClient interface {
 
 // GetHomes returns the list of Home ID's that are registered under the user's account.
 func GetHomes(ctx context.Context, client TadoClient) ([]tado.HomeBase, error) {
-	me, err := client.GetMeWithResponse(ctx)
+	me, err := Result(client.GetMeWithResponse(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("GetMeWithResponse: %w", err)
+		return nil, fmt.Errorf("GetMe: %w", err)
 	}
-	if me.StatusCode() != http.StatusOK {
-		return nil, HandleErrors(me.HTTPResponse, map[int]any{
-			http.StatusUnauthorized: me.JSON401,
-		})
+	if me.Homes == nil {
+		return nil, nil
 	}
-	return *me.JSON200.Homes, nil
+	return *me.Homes, nil
 }
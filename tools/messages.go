@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/clambin/tado/v2"
+)
+
+// Message is a human-friendly description of a Tadoº error code.
+type Message struct {
+	// Text describes the error.
+	Text string
+	// Fix suggests how the user can resolve the error. It may be empty.
+	Fix string
+}
+
+// String returns the text of the message, followed by the suggested fix, if any.
+func (m Message) String() string {
+	if m.Fix == "" {
+		return m.Text
+	}
+	return m.Text + " " + m.Fix
+}
+
+// ZoneTypeMismatch is the catalog key of the message for 422 errors that report a ZoneType. The Tadoº API only sets
+// an Error422's ZoneType when the operation isn't allowed for the zone's type, but doesn't document the error's code.
+// In the message, "{zoneType}" is replaced by the zone's type.
+const ZoneTypeMismatch = "zoneTypeMismatch"
+
+// DefaultMessages contains the messages for the error codes documented by the Tadoº API and for ZoneTypeMismatch,
+// by language and error code. Other error codes (e.g. the codes of 422 errors) aren't documented: applications can add
+// messages for the codes they receive with Catalog.Set. NewCatalog copies these messages, so changing DefaultMessages
+// only affects catalogs created afterwards.
+var DefaultMessages = map[string]map[string]Message{
+	"en": {
+		ZoneTypeMismatch: {
+			Text: "This operation is not available for {zoneType} zones.",
+			Fix:  "Select a zone that supports it, or only change the settings that the zone supports.",
+		},
+		"unauthorized": {
+			Text: "You are not logged in, or your session has expired.",
+			Fix:  "Log in again.",
+		},
+		"accessDenied": {
+			Text: "Your account does not have access to this home or device.",
+			Fix:  "Check that the home is shared with your account.",
+		},
+	},
+	"nl": {
+		ZoneTypeMismatch: {
+			Text: "Deze bewerking is niet beschikbaar voor {zoneType} zones.",
+			Fix:  "Kies een zone die ze ondersteunt, of wijzig enkel de instellingen die de zone ondersteunt.",
+		},
+		"unauthorized": {
+			Text: "Je bent niet aangemeld, of je sessie is verlopen.",
+			Fix:  "Meld je opnieuw aan.",
+		},
+		"accessDenied": {
+			Text: "Je account heeft geen toegang tot deze woning of dit toestel.",
+			Fix:  "Controleer of de woning met je account gedeeld is.",
+		},
+	},
+}
+
+// Catalog translates the errors returned by the Tadoº API into human-friendly messages:
+//
+//	catalog := tools.NewCatalog()
+//	if _, err := client.GetZones(ctx, homeId); err != nil {
+//		fmt.Println(catalog.Explain("nl-BE", err))
+//	}
+//
+// The catalog only contains messages for the error codes documented by the Tadoº API and for 422 errors caused by
+// the zone's type (see DefaultMessages). Since the codes of other errors (e.g. an invalid overlay termination or a
+// rejected presence lock) aren't documented, their messages are left to the caller. Callers can add messages for
+// these error codes, or other languages, with Set:
+//
+//	catalog.Set("en", "someCode", tools.Message{Text: "...", Fix: "..."})
+//
+// If the catalog has no message for an error code, the title of the error is used instead.
+type Catalog struct {
+	// Messages contains the messages by language (e.g. "en") and error code.
+	Messages map[string]map[string]Message
+	// Language is the language used when no message is found in the requested language.
+	Language string
+}
+
+// NewCatalog returns a Catalog with a copy of DefaultMessages, using English as fallback language.
+func NewCatalog() *Catalog {
+	c := Catalog{
+		Messages: make(map[string]map[string]Message, len(DefaultMessages)),
+		Language: "en",
+	}
+	for language, messages := range DefaultMessages {
+		c.Messages[language] = maps.Clone(messages)
+	}
+	return &c
+}
+
+// Set adds (or replaces) the message for an error code in a language.
+func (c *Catalog) Set(language, code string, message Message) {
+	if c.Messages == nil {
+		c.Messages = make(map[string]map[string]Message)
+	}
+	if c.Messages[language] == nil {
+		c.Messages[language] = make(map[string]Message)
+	}
+	c.Messages[language][code] = message
+}
+
+// Lookup returns the message for an error code. It first tries the requested language (e.g. "nl-BE"),
+// then its base language ("nl") and finally the catalog's fallback language.
+func (c *Catalog) Lookup(language, code string) (Message, bool) {
+	candidates := []string{language}
+	if base, _, ok := strings.Cut(language, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, c.Language)
+	for _, candidate := range candidates {
+		if m, ok := c.Messages[candidate][code]; ok {
+			return m, true
+		}
+	}
+	return Message{}, false
+}
+
+// Message returns the human-friendly message for a Tadoº error. If the catalog has no message for its code,
+// it returns the error's title (or its code, if it has no title).
+func (c *Catalog) Message(language string, err tado.Error) string {
+	return c.message(language, err.Code, err.Title)
+}
+
+// Message422 returns the human-friendly message for a Tadoº 422 error. If the catalog has no message for its code,
+// but the error reports a ZoneType (i.e. the operation isn't allowed for the zone's type), it returns the ZoneTypeMismatch message.
+// Otherwise, the message is followed by the zone type of the error, if any.
+func (c *Catalog) Message422(language string, err tado.Error422) string {
+	if err.ZoneType == nil {
+		return c.message(language, err.Code, err.Title)
+	}
+	if _, ok := c.Lookup(language, deref(err.Code)); !ok {
+		if m, ok := c.Lookup(language, ZoneTypeMismatch); ok {
+			return strings.ReplaceAll(m.String(), "{zoneType}", string(*err.ZoneType))
+		}
+	}
+	return c.message(language, err.Code, err.Title) + " (zoneType: " + string(*err.ZoneType) + ")"
+}
+
+func (c *Catalog) message(language string, code, title *string) string {
+	if m, ok := c.Lookup(language, deref(code)); ok {
+		return m.String()
+	}
+	if t := deref(title); t != "" {
+		return t
+	}
+	return deref(code)
+}
+
+// Explain returns the human-friendly messages for the Tadoº errors in err, one per line.
+// If err doesn't contain any Tadoº errors, it returns err.Error().
+func (c *Catalog) Explain(language string, err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors)+len(apiErr.Errors422) == 0 {
+		return err.Error()
+	}
+	messages := make([]string, 0, len(apiErr.Errors)+len(apiErr.Errors422))
+	for _, e := range apiErr.Errors {
+		messages = append(messages, c.Message(language, e))
+	}
+	for _, e := range apiErr.Errors422 {
+		messages = append(messages, c.Message422(language, e))
+	}
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestCatalog_Message(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		err      tado.Error
+		want     string
+	}{
+		{
+			name:     "known code",
+			language: "en",
+			err:      tado.Error{Code: VarP("unauthorized"), Title: VarP("bad token")},
+			want:     "You are not logged in, or your session has expired. Log in again.",
+		},
+		{
+			name:     "regional language",
+			language: "nl-BE",
+			err:      tado.Error{Code: VarP("unauthorized")},
+			want:     "Je bent niet aangemeld, of je sessie is verlopen. Meld je opnieuw aan.",
+		},
+		{
+			name:     "fallback language",
+			language: "fr",
+			err:      tado.Error{Code: VarP("accessDenied")},
+			want:     "Your account does not have access to this home or device. Check that the home is shared with your account.",
+		},
+		{
+			name:     "unknown code",
+			language: "en",
+			err:      tado.Error{Code: VarP("foo"), Title: VarP("error foo")},
+			want:     "error foo",
+		},
+		{
+			name:     "no title",
+			language: "en",
+			err:      tado.Error{Code: VarP("foo")},
+			want:     "foo",
+		},
+	}
+
+	c := NewCatalog()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Message(tt.language, tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_Message422(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		messages map[string]Message
+		err      tado.Error422
+		want     string
+	}{
+		{
+			name:     "zone type mismatch",
+			language: "en",
+			err:      tado.Error422{Code: VarP("foo"), Title: VarP("error foo"), ZoneType: VarP(tado.HOTWATER)},
+			want:     "This operation is not available for HOT_WATER zones. Select a zone that supports it, or only change the settings that the zone supports.",
+		},
+		{
+			name:     "zone type mismatch in another language",
+			language: "nl-BE",
+			err:      tado.Error422{Code: VarP("foo"), ZoneType: VarP(tado.AIRCONDITIONING)},
+			want:     "Deze bewerking is niet beschikbaar voor AIR_CONDITIONING zones. Kies een zone die ze ondersteunt, of wijzig enkel de instellingen die de zone ondersteunt.",
+		},
+		{
+			name:     "custom zone type mismatch",
+			language: "en",
+			messages: map[string]Message{ZoneTypeMismatch: {Text: "Not for {zoneType}.", Fix: "Use another zone."}},
+			err:      tado.Error422{Code: VarP("foo"), ZoneType: VarP(tado.HEATING)},
+			want:     "Not for HEATING. Use another zone.",
+		},
+		{
+			name:     "message for the code takes precedence",
+			language: "en",
+			messages: map[string]Message{"foo": {Text: "Foo failed."}},
+			err:      tado.Error422{Code: VarP("foo"), ZoneType: VarP(tado.HEATING)},
+			want:     "Foo failed. (zoneType: HEATING)",
+		},
+		{
+			name:     "no zone type",
+			language: "en",
+			err:      tado.Error422{Code: VarP("foo"), Title: VarP("error foo")},
+			want:     "error foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCatalog()
+			for code, m := range tt.messages {
+				c.Set("en", code, m)
+			}
+			if got := c.Message422(tt.language, tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_Set(t *testing.T) {
+	c := NewCatalog()
+	c.Set("fr", "unauthorized", Message{Text: "Vous n'êtes pas connecté."})
+	c.Set("en", "foo", Message{Text: "Foo failed.", Fix: "Try bar."})
+
+	if got, want := c.Message("fr", tado.Error{Code: VarP("unauthorized")}), "Vous n'êtes pas connecté."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := c.Message("fr", tado.Error{Code: VarP("foo")}), "Foo failed. Try bar."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// catalogs don't share their messages
+	if _, ok := NewCatalog().Lookup("en", "foo"); ok {
+		t.Error("Set modified DefaultMessages")
+	}
+}
+
+func TestCatalog_Explain(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("connection refused"),
+			want: "connection refused",
+		},
+		{
+			name: "no tado errors",
+			err:  HandleErrors(&http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}, nil),
+			want: "http: 502 - 502 Bad Gateway",
+		},
+		{
+			name: "tado errors",
+			err: fmt.Errorf("GetZones: %w", HandleErrors(&http.Response{StatusCode: http.StatusForbidden}, map[int]any{
+				http.StatusForbidden: &tado.ErrorResponse{Errors: &[]tado.Error{
+					{Code: VarP("accessDenied")},
+					{Code: VarP("foo"), Title: VarP("error foo")},
+				}},
+			})),
+			want: "Your account does not have access to this home or device. Check that the home is shared with your account.\nerror foo",
+		},
+		{
+			name: "422 errors",
+			err: HandleErrors(&http.Response{StatusCode: http.StatusUnprocessableEntity}, map[int]any{
+				http.StatusUnprocessableEntity: &tado.ErrorResponse422{Errors: &[]tado.Error422{
+					{Code: VarP("invalid"), Title: VarP("invalid value for zone type"), ZoneType: VarP(tado.HOTWATER)},
+				}},
+			}),
+			want: "This operation is not available for HOT_WATER zones. Select a zone that supports it, or only change the settings that the zone supports.",
+		},
+	}
+
+	c := NewCatalog()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Explain("en", tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A Response is a response of the generated client (e.g. *tado.DeleteZoneOverlayResponse).
+type Response interface {
+	StatusCode() int
+	Status() string
+}
+
+// A PayloadResponse is a response of the generated client that returns a payload (e.g. *tado.GetMeResponse).
+type PayloadResponse[T any] interface {
+	Response
+	GetJSON200() *T
+}
+
+// Result returns the payload of a response of the generated client, or an error if the call failed.
+// It replaces checking the status code and calling HandleErrors for each call:
+//
+//	me, err := tools.Result(client.GetMeWithResponse(ctx))
+//
+// If the server returned an error, Result returns an *APIError with the errors in the response's JSONnnn field
+// (e.g. JSON401) for the status code. A 204 No Content response is a success and returns the zero value of T.
+func Result[T any](resp PayloadResponse[T], err error) (T, error) {
+	var payload T
+	if err = Check(resp, err); err != nil {
+		return payload, err
+	}
+	if p := resp.GetJSON200(); p != nil {
+		payload = *p
+	}
+	return payload, nil
+}
+
+// Check returns an error if the call of the generated client failed. Use it for calls that don't return a payload:
+//
+//	err := tools.Check(client.DeleteZoneOverlayWithResponse(ctx, homeId, zoneId))
+//
+// Any 2xx response (including 204 No Content) is a success. Otherwise, Check returns an *APIError with the errors
+// in the response's JSONnnn field (e.g. JSON401) for the status code.
+func Check(resp Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if code := resp.StatusCode(); code >= http.StatusOK && code < http.StatusMultipleChoices {
+		return nil
+	}
+	httpResp, tadoErrors := responseErrors(resp)
+	if httpResp == nil {
+		httpResp = &http.Response{StatusCode: resp.StatusCode(), Status: resp.Status()}
+	}
+	return HandleErrors(httpResp, tadoErrors)
+}
+
+// responseErrors returns the http.Response of a generated response, and its error payloads (the JSONnnn fields for 4xx and 5xx status codes) by status code.
+func responseErrors(resp Response) (*http.Response, map[int]any) {
+	v := reflect.ValueOf(resp)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var httpResp *http.Response
+	tadoErrors := make(map[int]any)
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Name == "HTTPResponse" {
+			httpResp, _ = v.Field(i).Interface().(*http.Response)
+			continue
+		}
+		code, err := strconv.Atoi(strings.TrimPrefix(field.Name, "JSON"))
+		if err != nil || !strings.HasPrefix(field.Name, "JSON") || code < http.StatusBadRequest {
+			continue
+		}
+		tadoErrors[code] = v.Field(i).Interface()
+	}
+	return httpResp, tadoErrors
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"errors"
+	"github.com/clambin/tado/v2"
+	"net/http"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    *tado.GetMeResponse
+		err     error
+		want    string
+		wantErr error
+	}{
+		{
+			name: "success",
+			resp: &tado.GetMeResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+				JSON200:      &tado.User{Name: VarP("foo")},
+			},
+			want: "foo",
+		},
+		{
+			name: "no content",
+			resp: &tado.GetMeResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNoContent}},
+		},
+		{
+			name:    "call failed",
+			err:     errors.New("connection refused"),
+			wantErr: errors.New("connection refused"),
+		},
+		{
+			name: "unauthorized",
+			resp: &tado.GetMeResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusUnauthorized},
+				JSON401:      &tado.Unauthorized401{Errors: &[]tado.Error{{Code: VarP("unauthorized"), Title: VarP("bad token")}}},
+			},
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name:    "unexpected status",
+			resp:    &tado.GetMeResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"}},
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			me, err := Result(tt.resp, tt.err)
+			if (tt.wantErr != nil) != (err != nil) {
+				t.Fatalf("got err %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+					t.Errorf("got err %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if got := deref(me.Name); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    *tado.DeleteZoneOverlayResponse
+		wantErr error
+		want    string
+	}{
+		{
+			name: "no content",
+			resp: &tado.DeleteZoneOverlayResponse{HTTPResponse: &http.Response{StatusCode: http.StatusNoContent}},
+		},
+		{
+			name: "forbidden",
+			resp: &tado.DeleteZoneOverlayResponse{
+				HTTPResponse: &http.Response{StatusCode: http.StatusForbidden},
+				JSON403:      &tado.AccessDenied403{Errors: &[]tado.Error{{Code: VarP("accessDenied"), Title: VarP("access denied")}}},
+			},
+			wantErr: ErrForbidden,
+			want:    "tado: 403 - accessDenied - access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.resp, nil)
+			if (tt.wantErr != nil) != (err != nil) {
+				t.Fatalf("got err %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got err %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != "" && err.Error() != tt.want {
+				t.Errorf("got %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
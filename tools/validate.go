@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/clambin/tado/v2"
+)
+
+// ErrUnsupportedSetting is wrapped by every violation returned by ValidateZoneSetting.
+var ErrUnsupportedSetting = errors.New("unsupported zone setting")
+
+// ValidateZoneSetting checks that a zone supports setting, as described by its capabilities (see GetZoneCapabilities).
+// This catches invalid settings (e.g. a fan level or swing mode not supported by an air conditioning unit) before
+// they are sent to the Tadoº API, which would reject them with a 422:
+//
+//	capabilities, err := client.GetZoneCapabilities(ctx, homeId, zoneId)
+//	if err != nil {
+//		return err
+//	}
+//	if err = tools.ValidateZoneSetting(overlay.Setting, capabilities); err != nil {
+//		return err
+//	}
+//
+// ValidateZoneSetting checks the mode, temperature (range and step), fan level, swing and light of the setting.
+// If the setting turns the zone off, only its type is checked. It returns all violations, joined with errors.Join.
+// Each violation wraps ErrUnsupportedSetting.
+func ValidateZoneSetting(setting *tado.ZoneSetting, capabilities tado.ZoneCapabilities) error {
+	if setting == nil {
+		return nil
+	}
+	var errs []error
+	if setting.Type != nil && capabilities.Type != nil && *setting.Type != *capabilities.Type {
+		errs = append(errs, violation("type %s does not match zone type %s", *setting.Type, *capabilities.Type))
+	}
+	if deref(setting.Power) == tado.PowerOFF {
+		return errors.Join(errs...)
+	}
+
+	zoneType := deref(capabilities.Type)
+	if zoneType == "" {
+		zoneType = deref(setting.Type)
+	}
+	if zoneType == tado.AIRCONDITIONING {
+		errs = append(errs, validateAirConditioning(setting, capabilities)...)
+	} else {
+		errs = append(errs, validateHeating(setting, capabilities)...)
+	}
+	return errors.Join(errs...)
+}
+
+// validateHeating validates the setting of a HEATING or HOT_WATER zone.
+func validateHeating(setting *tado.ZoneSetting, capabilities tado.ZoneCapabilities) []error {
+	var errs []error
+	if setting.Mode != nil {
+		errs = append(errs, violation("mode %s not supported", *setting.Mode))
+	}
+	errs = append(errs, validateOption("fan level", setting.FanLevel, nil)...)
+	errs = append(errs, validateOption("horizontal swing", setting.HorizontalSwing, nil)...)
+	errs = append(errs, validateOption("vertical swing", setting.VerticalSwing, nil)...)
+	errs = append(errs, validateOption("light", setting.Light, nil)...)
+	if setting.Temperature != nil && capabilities.CanSetTemperature != nil && !*capabilities.CanSetTemperature {
+		return append(errs, violation("temperature cannot be set"))
+	}
+	return append(errs, validateTemperature(setting.Temperature, capabilities.Temperatures)...)
+}
+
+// validateAirConditioning validates the setting of an AIR_CONDITIONING zone against the capabilities of its mode.
+func validateAirConditioning(setting *tado.ZoneSetting, capabilities tado.ZoneCapabilities) []error {
+	if setting.Mode == nil {
+		return []error{violation("mode is required")}
+	}
+	mode, ok := modeCapabilities(*setting.Mode, capabilities)
+	if !ok {
+		return []error{violation("mode %s not supported", *setting.Mode)}
+	}
+	var errs []error
+	errs = append(errs, validateOption("fan level", setting.FanLevel, mode.FanLevel)...)
+	errs = append(errs, validateOption("horizontal swing", setting.HorizontalSwing, mode.HorizontalSwing)...)
+	errs = append(errs, validateOption("vertical swing", setting.VerticalSwing, mode.VerticalSwing)...)
+	errs = append(errs, validateOption("light", setting.Light, mode.Light)...)
+	return append(errs, validateTemperature(setting.Temperature, mode.Temperatures)...)
+}
+
+// modeCapabilities returns the capabilities of an air conditioning mode. AUTO mode has no temperature capabilities.
+func modeCapabilities(mode tado.AirConditioningMode, capabilities tado.ZoneCapabilities) (tado.AirConditioningModeCapabilities, bool) {
+	var c *tado.AirConditioningModeCapabilities
+	switch mode {
+	case tado.AirConditioningModeAUTO:
+		if capabilities.AUTO == nil {
+			return tado.AirConditioningModeCapabilities{}, false
+		}
+		return tado.AirConditioningModeCapabilities{
+			FanLevel:        capabilities.AUTO.FanLevel,
+			HorizontalSwing: capabilities.AUTO.HorizontalSwing,
+			Light:           capabilities.AUTO.Light,
+			VerticalSwing:   capabilities.AUTO.VerticalSwing,
+		}, true
+	case tado.AirConditioningModeCOOL:
+		c = capabilities.COOL
+	case tado.AirConditioningModeDRY:
+		c = capabilities.DRY
+	case tado.AirConditioningModeFAN:
+		c = capabilities.FAN
+	case tado.AirConditioningModeHEAT:
+		c = capabilities.HEAT
+	}
+	if c == nil {
+		return tado.AirConditioningModeCapabilities{}, false
+	}
+	return *c, true
+}
+
+// validateOption checks that value, if set, is one of the supported values.
+func validateOption[T ~string](name string, value *T, supported *[]T) []error {
+	if value == nil {
+		return nil
+	}
+	if supported == nil || len(*supported) == 0 {
+		return []error{violation("%s not supported", name)}
+	}
+	if !slices.Contains(*supported, *value) {
+		return []error{violation("%s %s not supported (supported: %v)", name, *value, *supported)}
+	}
+	return nil
+}
+
+// validateTemperature checks the temperature against the range and step of the capability.
+func validateTemperature(temperature *tado.Temperature, capability *tado.TemperatureCapability) []error {
+	if temperature == nil || (temperature.Celsius == nil && temperature.Fahrenheit == nil) {
+		return nil
+	}
+	if capability == nil {
+		return []error{violation("temperature not supported")}
+	}
+	var errs []error
+	if temperature.Celsius != nil && capability.Celsius != nil {
+		errs = append(errs, validateRange("°C", *temperature.Celsius, capability.Celsius.Min, capability.Celsius.Max, capability.Celsius.Step)...)
+	}
+	if temperature.Fahrenheit != nil && capability.Fahrenheit != nil {
+		errs = append(errs, validateRange("°F", *temperature.Fahrenheit, capability.Fahrenheit.Min, capability.Fahrenheit.Max, capability.Fahrenheit.Step)...)
+	}
+	return errs
+}
+
+func validateRange(unit string, value float32, minValue, maxValue *int, step *float32) []error {
+	var errs []error
+	if minValue != nil && value < float32(*minValue) {
+		errs = append(errs, violation("temperature %g%s below minimum of %d%s", value, unit, *minValue, unit))
+	}
+	if maxValue != nil && value > float32(*maxValue) {
+		errs = append(errs, violation("temperature %g%s above maximum of %d%s", value, unit, *maxValue, unit))
+	}
+	if step != nil && *step > 0 {
+		// steps are relative to the minimum temperature, if known
+		offset := float64(value)
+		if minValue != nil {
+			offset -= float64(*minValue)
+		}
+		steps := offset / float64(*step)
+		// allow for rounding errors: step is a float32 (e.g. 0.1)
+		if math.Abs(steps-math.Round(steps)) > 1e-3 {
+			errs = append(errs, violation("temperature %g%s is not a multiple of %g%s", value, unit, *step, unit))
+		}
+	}
+	return errs
+}
+
+func violation(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrUnsupportedSetting}, args...)...)
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestValidateZoneSetting(t *testing.T) {
+	heating := tado.ZoneCapabilities{
+		Type:         VarP(tado.HEATING),
+		Temperatures: temperatureCapability(5, 25, 0.1),
+	}
+	airco := tado.ZoneCapabilities{
+		Type: VarP(tado.AIRCONDITIONING),
+		AUTO: &tado.AirConditioningModeCapabilitiesBase{
+			FanLevel: &[]tado.FanLevel{"AUTO"},
+		},
+		COOL: &tado.AirConditioningModeCapabilities{
+			FanLevel:      &[]tado.FanLevel{"LEVEL1", "LEVEL2", "AUTO"},
+			VerticalSwing: &[]tado.VerticalSwing{"ON", "OFF"},
+			Temperatures:  temperatureCapability(18, 30, 1),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		setting      *tado.ZoneSetting
+		capabilities tado.ZoneCapabilities
+		want         []string
+	}{
+		{
+			name:         "nil",
+			capabilities: heating,
+		},
+		{
+			name:         "heating",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HEATING), Power: VarP(tado.PowerON), Temperature: &tado.Temperature{Celsius: VarP[float32](21.3)}},
+			capabilities: heating,
+		},
+		{
+			name:         "heating off",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HEATING), Power: VarP(tado.PowerOFF), Temperature: &tado.Temperature{Celsius: VarP[float32](50)}},
+			capabilities: heating,
+		},
+		{
+			name:         "type mismatch",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HOTWATER), Power: VarP(tado.PowerOFF)},
+			capabilities: heating,
+			want:         []string{"type HOT_WATER does not match zone type HEATING"},
+		},
+		{
+			name:         "heating out of range",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HEATING), Power: VarP(tado.PowerON), Temperature: &tado.Temperature{Celsius: VarP[float32](25.05)}},
+			capabilities: heating,
+			want:         []string{"above maximum of 25°C", "is not a multiple of 0.1°C"},
+		},
+		{
+			name:         "heating with air conditioning settings",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HEATING), Power: VarP(tado.PowerON), Mode: VarP(tado.AirConditioningModeCOOL), FanLevel: VarP[tado.FanLevel]("AUTO")},
+			capabilities: heating,
+			want:         []string{"mode COOL not supported", "fan level not supported"},
+		},
+		{
+			name:         "hot water",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.HOTWATER), Power: VarP(tado.PowerON), Temperature: &tado.Temperature{Celsius: VarP[float32](55)}},
+			capabilities: tado.ZoneCapabilities{Type: VarP(tado.HOTWATER), CanSetTemperature: VarP(false)},
+			want:         []string{"temperature cannot be set"},
+		},
+		{
+			name:         "air conditioning",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.AIRCONDITIONING), Power: VarP(tado.PowerON), Mode: VarP(tado.AirConditioningModeCOOL), FanLevel: VarP[tado.FanLevel]("LEVEL2"), VerticalSwing: VarP[tado.VerticalSwing]("ON"), Temperature: &tado.Temperature{Celsius: VarP[float32](21)}},
+			capabilities: airco,
+		},
+		{
+			name:         "air conditioning without mode",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.AIRCONDITIONING), Power: VarP(tado.PowerON)},
+			capabilities: airco,
+			want:         []string{"mode is required"},
+		},
+		{
+			name:         "unsupported mode",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.AIRCONDITIONING), Power: VarP(tado.PowerON), Mode: VarP(tado.AirConditioningModeDRY)},
+			capabilities: airco,
+			want:         []string{"mode DRY not supported"},
+		},
+		{
+			name:         "auto mode",
+			setting:      &tado.ZoneSetting{Type: VarP(tado.AIRCONDITIONING), Power: VarP(tado.PowerON), Mode: VarP(tado.AirConditioningModeAUTO), FanLevel: VarP[tado.FanLevel]("AUTO"), Temperature: &tado.Temperature{Celsius: VarP[float32](21)}},
+			capabilities: airco,
+			want:         []string{"temperature not supported"},
+		},
+		{
+			name: "all violations",
+			setting: &tado.ZoneSetting{
+				Type:            VarP(tado.AIRCONDITIONING),
+				Power:           VarP(tado.PowerON),
+				Mode:            VarP(tado.AirConditioningModeCOOL),
+				FanLevel:        VarP[tado.FanLevel]("LEVEL5"),
+				HorizontalSwing: VarP[tado.HorizontalSwing]("LEFT"),
+				VerticalSwing:   VarP[tado.VerticalSwing]("MID"),
+				Light:           VarP[tado.Light]("ON"),
+				Temperature:     &tado.Temperature{Celsius: VarP[float32](16.5)},
+			},
+			capabilities: airco,
+			want: []string{
+				"fan level LEVEL5 not supported (supported: [LEVEL1 LEVEL2 AUTO])",
+				"horizontal swing not supported",
+				"vertical swing MID not supported (supported: [ON OFF])",
+				"light not supported",
+				"temperature 16.5°C below minimum of 18°C",
+				"temperature 16.5°C is not a multiple of 1°C",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateZoneSetting(tt.setting, tt.capabilities)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrUnsupportedSetting) {
+				t.Fatalf("got err %v, want %v", err, ErrUnsupportedSetting)
+			}
+			violations := err.(interface{ Unwrap() []error }).Unwrap()
+			if len(violations) != len(tt.want) {
+				t.Fatalf("got %d violations, want %d: %v", len(violations), len(tt.want), err)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(violations[i].Error(), want) {
+					t.Errorf("violation %d: got %q, want %q", i, violations[i], want)
+				}
+			}
+		})
+	}
+}
+
+func temperatureCapability(minValue, maxValue int, step float32) *tado.TemperatureCapability {
+	var c tado.TemperatureCapability
+	c.Celsius = &struct {
+		Max  *int     `json:"max,omitempty"`
+		Min  *int     `json:"min,omitempty"`
+		Step *float32 `json:"step,omitempty"`
+	}{Max: &maxValue, Min: &minValue, Step: &step}
+	return &c
+}
@@ -0,0 +1,135 @@
+// Package tracing creates OpenTelemetry spans for calls to the Tadoº API.
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/clambin/tado/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/clambin/tado/v2/tracing"
+
+// Span attributes specific to the Tadoº API.
+const (
+	HomeIDKey     = attribute.Key("tado.home_id")
+	ZoneIDKey     = attribute.Key("tado.zone_id")
+	ErrorCodesKey = attribute.Key("tado.error_codes")
+)
+
+// maxErrorBodySize is the largest error response parsed for Tadoº error codes.
+const maxErrorBodySize = 64 << 10
+
+var _ http.RoundTripper = &Transport{}
+
+// Transport is an http.RoundTripper that creates a client span for every call to the Tadoº API.
+//
+// Spans are named after the generated operation (e.g. "GetZoneState") and have attributes for the home ID and
+// zone ID in the request, the HTTP status code and, for failed calls, the error codes in the ErrorResponse.
+// Since the generated client passes the caller's context with the request, the spans are children of the caller's span.
+// The trace context is propagated to the server with the configured Propagator.
+type Transport struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// TracerProvider creates the Tracer. If nil, the global TracerProvider is used.
+	TracerProvider trace.TracerProvider
+	// Propagator injects the trace context in the request. If nil, the global TextMapPropagator is used.
+	Propagator propagation.TextMapPropagator
+}
+
+// NewTransport returns a Transport that sends its requests to next, using the global TracerProvider and TextMapPropagator.
+func NewTransport(next http.RoundTripper) *Transport {
+	return &Transport{Next: next}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := "HTTP " + req.Method
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if op, params, ok := tado.LookupOperation(req); ok {
+		name = op.ID
+		attrs = append(attrs, semconv.URLTemplate(op.Path))
+		if homeID, ok := params["homeId"]; ok {
+			attrs = append(attrs, HomeIDKey.String(homeID))
+		}
+		if zoneID, ok := params["zoneId"]; ok {
+			attrs = append(attrs, ZoneIDKey.String(zoneID))
+		}
+	}
+
+	ctx, span := t.tracer().Start(req.Context(), name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	req = req.Clone(ctx)
+	t.propagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+		if errorCodes := parseErrorCodes(resp); len(errorCodes) > 0 {
+			span.SetAttributes(ErrorCodesKey.StringSlice(errorCodes))
+		}
+	}
+	return resp, nil
+}
+
+func (t *Transport) tracer() trace.Tracer {
+	tp := t.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(instrumentationName, trace.WithSchemaURL(semconv.SchemaURL))
+}
+
+func (t *Transport) propagator() propagation.TextMapPropagator {
+	if t.Propagator != nil {
+		return t.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// parseErrorCodes returns the error codes in the response's ErrorResponse. The response body is restored,
+// so the caller can still read it.
+func parseErrorCodes(resp *http.Response) []string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+	if err != nil {
+		return nil
+	}
+	// ErrorResponse422 is a superset of ErrorResponse
+	var errorResponse tado.ErrorResponse422
+	if json.Unmarshal(body, &errorResponse) != nil || errorResponse.Errors == nil {
+		return nil
+	}
+	errorCodes := make([]string, 0, len(*errorResponse.Errors))
+	for _, e := range *errorResponse.Errors {
+		if e.Code != nil {
+			errorCodes = append(errorCodes, *e.Code)
+		}
+	}
+	return errorCodes
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTransport(t *testing.T) {
+	var traceparent string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"zoneTypeMismatch","title":"zone type mismatch","zoneType":"HOT_WATER"}]}`))
+	}))
+	defer s.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	transport := Transport{TracerProvider: tp, Propagator: propagation.TraceContext{}}
+	client, err := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(&http.Client{Transport: &transport}))
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	resp, err := client.SetZoneOverlayWithResponse(ctx, 1, 2, tado.SetZoneOverlayJSONRequestBody{})
+	parent.End()
+	if err != nil {
+		t.Fatalf("SetZoneOverlay failed: %v", err)
+	}
+	// the body is still available to the client
+	if resp.JSON422 == nil || len(*resp.JSON422.Errors) != 1 {
+		t.Fatalf("unexpected response: %s", string(resp.Body))
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "SetZoneOverlay" {
+		t.Errorf("got span %q, want SetZoneOverlay", span.Name)
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("span is not a child of the caller's span")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("got status %v, want %v", span.Status.Code, codes.Error)
+	}
+	want := []attribute.KeyValue{
+		HomeIDKey.String("1"),
+		ZoneIDKey.String("2"),
+		attribute.Int("http.response.status_code", http.StatusUnprocessableEntity),
+		ErrorCodesKey.StringSlice([]string{"zoneTypeMismatch"}),
+	}
+	for _, attr := range want {
+		if !slices.ContainsFunc(span.Attributes, func(kv attribute.KeyValue) bool { return kv == attr }) {
+			t.Errorf("missing attribute %s=%s", attr.Key, attr.Value.Emit())
+		}
+	}
+	if want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("got traceparent %q, want %q", traceparent, want)
+	}
+}
@@ -0,0 +1,146 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/clambin/tado/v2"
+)
+
+// DefaultTTLs contains the time-to-live of resources that rarely change.
+var DefaultTTLs = map[string]time.Duration{
+	"GetHome":             time.Hour,
+	"GetZones":            time.Hour,
+	"GetZoneCapabilities": time.Hour,
+	"GetDevices":          time.Hour,
+	"GetInstallations":    time.Hour,
+}
+
+var _ http.RoundTripper = &Cache{}
+
+// A Cache is an http.RoundTripper that caches successful GET responses, so that rarely changing resources
+// don't use up the API quota.
+//
+// Each resource has its own time-to-live, keyed by the ID of the generated operation (e.g. "GetZones")
+// or by its path template (e.g. "/homes/{homeId}/zones"). Operations without a time-to-live are not cached.
+// When a cached response expires and the server provided an ETag, Cache revalidates the response with
+// If-None-Match, rather than downloading it again.
+//
+// Any other request (e.g. SetZoneOverlay) invalidates all cached responses for the same home. Requests that
+// don't belong to a home (e.g. SetChildLock) invalidate the entire cache.
+//
+// Cache doesn't distinguish between users, so it should not be shared between http.Clients for different accounts.
+type Cache struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// TTLs contains the time-to-live of each cached resource, either by operation ID or by path template.
+	TTLs    map[string]time.Duration
+	entries map[string]*cacheEntry
+	lock    sync.Mutex
+}
+
+type cacheEntry struct {
+	expires time.Time
+	header  http.Header
+	homeID  string
+	etag    string
+	body    []byte
+}
+
+// NewCache returns a Cache that sends its requests to next, using DefaultTTLs.
+func NewCache(next http.RoundTripper) *Cache {
+	return &Cache{Next: next, TTLs: maps.Clone(DefaultTTLs)}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, params, ok := tado.LookupOperation(req)
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp, err := next(c.Next).RoundTrip(req)
+		c.invalidate(params["homeId"], ok)
+		return resp, err
+	}
+	ttl := c.ttl(op, ok)
+	if req.Method != http.MethodGet || ttl <= 0 {
+		return next(c.Next).RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	c.lock.Lock()
+	entry := c.entries[key]
+	c.lock.Unlock()
+	if entry != nil && time.Now().Before(entry.expires) {
+		return entry.response(req), nil
+	}
+	if entry != nil && entry.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := next(c.Next).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil && entry.etag != "":
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		entry = &cacheEntry{expires: time.Now().Add(ttl), header: entry.header, homeID: entry.homeID, etag: entry.etag, body: entry.body}
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		entry = &cacheEntry{expires: time.Now().Add(ttl), header: resp.Header.Clone(), homeID: params["homeId"], etag: resp.Header.Get("ETag"), body: body}
+	default:
+		return resp, nil
+	}
+	c.lock.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*cacheEntry)
+	}
+	c.entries[key] = entry
+	c.lock.Unlock()
+	return entry.response(req), nil
+}
+
+func (c *Cache) ttl(op tado.Operation, ok bool) time.Duration {
+	if !ok {
+		return 0
+	}
+	if ttl, ok := c.TTLs[op.ID]; ok {
+		return ttl
+	}
+	return c.TTLs[op.Path]
+}
+
+// invalidate removes all cached responses for the home. If the request doesn't belong to a home, the entire cache is cleared.
+func (c *Cache) invalidate(homeID string, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, entry := range c.entries {
+		if !ok || homeID == "" || entry.homeID == homeID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// response returns a copy of the cached response.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
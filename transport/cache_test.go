@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestCache(t *testing.T) {
+	var calls, notModified atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/zones") {
+			_, _ = w.Write([]byte(`[{"id":1,"name":"living room"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	c := NewCache(nil)
+	client, err := tado.NewClientWithResponses(s.URL+"/api/v2", tado.WithHTTPClient(&http.Client{Transport: c}))
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	getZones := func(homeID tado.HomeId) {
+		t.Helper()
+		resp, err := client.GetZonesWithResponse(t.Context(), homeID)
+		if err != nil {
+			t.Fatalf("GetZones failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil || len(*resp.JSON200) != 1 {
+			t.Fatalf("unexpected response: %d - %s", resp.StatusCode(), string(resp.Body))
+		}
+	}
+	wantCalls := func(want int32) {
+		t.Helper()
+		if got := calls.Swap(0); got != want {
+			t.Errorf("got %d calls, want %d", got, want)
+		}
+	}
+
+	// cached
+	getZones(1)
+	getZones(1)
+	getZones(2)
+	wantCalls(2)
+
+	// not cached
+	for range 2 {
+		if _, err = client.GetZoneStatesWithResponse(t.Context(), 1); err != nil {
+			t.Fatalf("GetZoneStates failed: %v", err)
+		}
+	}
+	wantCalls(2)
+
+	// a write invalidates the cache for that home
+	if _, err = client.DeleteZoneOverlayWithResponse(t.Context(), 1, 1); err != nil {
+		t.Fatalf("DeleteZoneOverlay failed: %v", err)
+	}
+	wantCalls(1)
+	getZones(1)
+	getZones(2)
+	wantCalls(1)
+
+	// expired responses are revalidated
+	c.TTLs = map[string]time.Duration{"/homes/{homeId}/zones": time.Millisecond}
+	if _, err = client.DeleteZoneOverlayWithResponse(t.Context(), 1, 1); err != nil {
+		t.Fatalf("DeleteZoneOverlay failed: %v", err)
+	}
+	getZones(1)
+	time.Sleep(10 * time.Millisecond)
+	getZones(1)
+	wantCalls(3)
+	if got := notModified.Load(); got != 1 {
+		t.Errorf("got %d revalidations, want 1", got)
+	}
+}
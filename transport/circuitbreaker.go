@@ -0,0 +1,198 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default settings of CircuitBreaker.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenDuration     = 30 * time.Second
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed indicates that requests are sent to the server.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen indicates that the server is unavailable: requests fail immediately.
+	CircuitOpen
+	// CircuitHalfOpen indicates that a single request is sent to the server, to probe whether it is available again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is returned when the CircuitBreaker refuses a request because the server is unavailable.
+// The returned error is a *CircuitOpenError, which reports when the CircuitBreaker will probe the server again.
+var ErrCircuitOpen = errors.New("tado api unavailable: circuit breaker open")
+
+// CircuitOpenError is returned by CircuitBreaker when it refuses a request.
+type CircuitOpenError struct {
+	// Retry is the time when the CircuitBreaker allows a request to probe the server.
+	Retry time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrCircuitOpen, e.Retry.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+var _ http.RoundTripper = &CircuitBreaker{}
+
+// A CircuitBreaker is an http.RoundTripper that stops sending requests when the server is unavailable.
+//
+// After FailureThreshold consecutive failures (network errors, timeouts or 5xx responses), the circuit opens:
+// all requests fail immediately with a *CircuitOpenError. After OpenDuration, the circuit becomes half-open and
+// a single request is sent to probe the server. If it succeeds, the circuit closes. Otherwise, it opens again.
+//
+// Use State to report the availability of the server, e.g. in a health endpoint.
+type CircuitBreaker struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// OnStateChange, if set, is called when the state of the circuit changes.
+	OnStateChange func(from, to CircuitState)
+	// FailureThreshold is the number of consecutive failures that opens the circuit. If zero, DefaultFailureThreshold is used.
+	FailureThreshold int
+	// OpenDuration is the time the circuit stays open before probing the server. If zero, DefaultOpenDuration is used.
+	OpenDuration time.Duration
+	openedAt     time.Time
+	state        CircuitState
+	failures     int
+	probing      bool
+	lock         sync.Mutex
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that sends its requests to next, using the default settings.
+func NewCircuitBreaker(next http.RoundTripper) *CircuitBreaker {
+	return &CircuitBreaker{Next: next}
+}
+
+// State returns the current state of the circuit.
+func (c *CircuitBreaker) State() CircuitState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// report an open circuit as half-open once the next request would probe the server
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= c.openDuration() {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *CircuitBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	probe, err := c.allow()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next(c.Next).RoundTrip(req)
+	c.record(req.Context(), probe, resp, err)
+	return resp, err
+}
+
+// allow reports whether a request may be sent to the server and whether that request probes the server.
+func (c *CircuitBreaker) allow() (bool, error) {
+	var change stateChange
+	defer change.notify(c.OnStateChange)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.state == CircuitOpen {
+		if retry := c.openedAt.Add(c.openDuration()); time.Now().Before(retry) {
+			return false, &CircuitOpenError{Retry: retry}
+		}
+		c.setState(CircuitHalfOpen, &change)
+	}
+	if c.state == CircuitHalfOpen {
+		// only one request probes the server
+		if c.probing {
+			return false, &CircuitOpenError{Retry: time.Now().Add(c.openDuration())}
+		}
+		c.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the state of the circuit with the outcome of a request. While the circuit is open or half-open,
+// only the probe changes its state: requests sent before the circuit opened are ignored.
+func (c *CircuitBreaker) record(ctx context.Context, probe bool, resp *http.Response, err error) {
+	var change stateChange
+	defer change.notify(c.OnStateChange)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if probe {
+		c.probing = false
+	} else if c.state != CircuitClosed {
+		return
+	}
+	switch {
+	case err != nil && (errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, ErrQuotaExhausted)):
+		// not a failure of the server
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		c.failures++
+		if probe || c.failures >= c.failureThreshold() {
+			c.openedAt = time.Now()
+			c.setState(CircuitOpen, &change)
+		}
+	default:
+		c.failures = 0
+		c.setState(CircuitClosed, &change)
+	}
+}
+
+// setState changes the state of the circuit and records the transition in change.
+// The caller must hold the lock and call change.notify once the lock is released.
+func (c *CircuitBreaker) setState(state CircuitState, change *stateChange) {
+	if state == c.state {
+		return
+	}
+	*change = stateChange{from: c.state, to: state, changed: true}
+	c.state = state
+}
+
+// stateChange records a transition of the circuit, so OnStateChange can be called without holding the lock.
+// This allows OnStateChange to call State.
+type stateChange struct {
+	from, to CircuitState
+	changed  bool
+}
+
+func (s *stateChange) notify(onStateChange func(from, to CircuitState)) {
+	if s.changed && onStateChange != nil {
+		onStateChange(s.from, s.to)
+	}
+}
+
+func (c *CircuitBreaker) failureThreshold() int {
+	if c.FailureThreshold <= 0 {
+		return DefaultFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+func (c *CircuitBreaker) openDuration() time.Duration {
+	if c.OpenDuration <= 0 {
+		return DefaultOpenDuration
+	}
+	return c.OpenDuration
+}
@@ -0,0 +1,218 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer s.Close()
+
+	var transitions []string
+	c := NewCircuitBreaker(nil)
+	c.FailureThreshold = 3
+	c.OpenDuration = 100 * time.Millisecond
+	c.OnStateChange = func(from, to CircuitState) { transitions = append(transitions, from.String()+"->"+to.String()) }
+	client := http.Client{Transport: c}
+	get := func() error {
+		resp, err := client.Get(s.URL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	// failures open the circuit
+	for range 3 {
+		if err := get(); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+	}
+	if state := c.State(); state != CircuitOpen {
+		t.Fatalf("got state %v, want %v", state, CircuitOpen)
+	}
+	// open circuit fails fast
+	err := get()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	var circuitErr *CircuitOpenError
+	if !errors.As(err, &circuitErr) || circuitErr.Retry.IsZero() {
+		t.Errorf("got %v, want CircuitOpenError", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("got %d calls, want 3", got)
+	}
+
+	// failed probe opens the circuit again
+	time.Sleep(c.OpenDuration)
+	if state := c.State(); state != CircuitHalfOpen {
+		t.Fatalf("got state %v, want %v", state, CircuitHalfOpen)
+	}
+	if err = get(); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if state := c.State(); state != CircuitOpen {
+		t.Fatalf("got state %v, want %v", state, CircuitOpen)
+	}
+
+	// successful probe closes the circuit
+	down.Store(false)
+	time.Sleep(c.OpenDuration)
+	if err = get(); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if state := c.State(); state != CircuitClosed {
+		t.Fatalf("got state %v, want %v", state, CircuitClosed)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("got transitions %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("got transitions %v, want %v", transitions, want)
+			break
+		}
+	}
+}
+
+func TestCircuitBreaker_Timeout(t *testing.T) {
+	c := CircuitBreaker{
+		Next:             roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("i/o timeout") }),
+		FailureThreshold: 1,
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if _, err := c.RoundTrip(req); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := c.RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("got %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreaker_OnStateChangeCallsState(t *testing.T) {
+	var c *CircuitBreaker
+	var states []CircuitState
+	c = &CircuitBreaker{
+		Next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+		}),
+		FailureThreshold: 1,
+		OnStateChange:    func(_, _ CircuitState) { states = append(states, c.State()) },
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := c.RoundTrip(req); err != nil {
+			t.Errorf("RoundTrip failed: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RoundTrip deadlocked")
+	}
+	if len(states) != 1 || states[0] != CircuitOpen {
+		t.Errorf("got states %v, want [open]", states)
+	}
+}
+
+func TestCircuitBreaker_StaleRequest(t *testing.T) {
+	// stale is sent while the circuit is closed, but completes once the circuit is half-open
+	stale := make(chan struct{})
+	staleSent := make(chan struct{})
+	probe := make(chan struct{})
+	var fail atomic.Bool
+	c := CircuitBreaker{
+		Next: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/stale":
+				close(staleSent)
+				<-stale
+			case "/probe":
+				<-probe
+			}
+			if fail.Load() {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		FailureThreshold: 1,
+		OpenDuration:     50 * time.Millisecond,
+	}
+	do := func(path string) error {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		_, err := c.RoundTrip(req)
+		return err
+	}
+
+	staleDone := make(chan error)
+	go func() { staleDone <- do("/stale") }()
+	<-staleSent
+
+	// open the circuit
+	fail.Store(true)
+	_ = do("/")
+	if state := c.State(); state != CircuitOpen {
+		t.Fatalf("got state %v, want %v", state, CircuitOpen)
+	}
+
+	// start probing
+	time.Sleep(c.OpenDuration)
+	fail.Store(false)
+	probeDone := make(chan error)
+	go func() { probeDone <- do("/probe") }()
+	for c.State() != CircuitHalfOpen || !c.isProbing() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the stale request succeeds, but doesn't close the circuit, nor allow another probe
+	close(stale)
+	if err := <-staleDone; err != nil {
+		t.Fatalf("stale request failed: %v", err)
+	}
+	if state := c.State(); state != CircuitHalfOpen {
+		t.Errorf("got state %v, want %v", state, CircuitHalfOpen)
+	}
+	if err := do("/"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("got %v, want ErrCircuitOpen", err)
+	}
+
+	// the probe closes the circuit
+	close(probe)
+	if err := <-probeDone; err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if state := c.State(); state != CircuitClosed {
+		t.Errorf("got state %v, want %v", state, CircuitClosed)
+	}
+}
+
+func (c *CircuitBreaker) isProbing() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.probing
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
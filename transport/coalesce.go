@@ -0,0 +1,95 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+var _ http.RoundTripper = &Coalescer{}
+
+// A Coalescer is an http.RoundTripper that merges concurrent identical GET requests into a single upstream request.
+// Each caller receives its own copy of the response.
+//
+// Requests are identical if they have the same URL and Authorization header.
+type Coalescer struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next  http.RoundTripper
+	calls map[string]*coalescedCall
+	saved atomic.Int64
+	lock  sync.Mutex
+}
+
+type coalescedCall struct {
+	done chan struct{}
+	resp *http.Response
+	err  error
+	body []byte
+}
+
+// NewCoalescer returns a Coalescer that sends its requests to next.
+func NewCoalescer(next http.RoundTripper) *Coalescer {
+	return &Coalescer{Next: next}
+}
+
+// Saved returns the number of requests that were not sent upstream, because they were merged with an identical request.
+func (c *Coalescer) Saved() int64 {
+	return c.saved.Load()
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *Coalescer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || (req.Body != nil && req.Body != http.NoBody) {
+		return next(c.Next).RoundTrip(req)
+	}
+
+	key := req.Header.Get("Authorization") + " " + req.URL.String()
+	c.lock.Lock()
+	if c.calls == nil {
+		c.calls = make(map[string]*coalescedCall)
+	}
+	if call, ok := c.calls[key]; ok {
+		c.lock.Unlock()
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		// if the first request was canceled, but ours wasn't, send our own request
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			return next(c.Next).RoundTrip(req)
+		}
+		c.saved.Add(1)
+		return call.response(req)
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.lock.Unlock()
+
+	call.resp, call.err = next(c.Next).RoundTrip(req)
+	if call.err == nil {
+		call.body, call.err = io.ReadAll(call.resp.Body)
+		_ = call.resp.Body.Close()
+	}
+	c.lock.Lock()
+	delete(c.calls, key)
+	c.lock.Unlock()
+	close(call.done)
+	return call.response(req)
+}
+
+// response returns a copy of the response.
+func (c *coalescedCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(c.body))
+	resp.Request = req
+	return &resp, nil
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescer(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer s.Close()
+
+	c := NewCoalescer(nil)
+	client := http.Client{Transport: c}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Go(func() {
+			resp, err := client.Get(s.URL)
+			if err != nil {
+				t.Errorf("Get failed: %v", err)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if body, _ := io.ReadAll(resp.Body); string(body) != "hello" {
+				t.Errorf("got body %q", body)
+			}
+		})
+	}
+	// give all callers time to send their requests
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("got %d calls, want 1", got)
+	}
+	if got := c.Saved(); got != callers-1 {
+		t.Errorf("got %d saved calls, want %d", got, callers-1)
+	}
+}
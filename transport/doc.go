@@ -0,0 +1,12 @@
+// Package transport contains http.RoundTripper middleware for the Tadoº API.
+//
+// Each middleware wraps the next http.RoundTripper in the chain, so they can be combined with the http.Client
+// returned by tado.NewOAuth2Client:
+//
+//	httpClient, err := tado.NewOAuth2Client(ctx, tado.WithEncryptedFileTokenStore("token.enc", "passphrase"))
+//	if err != nil {
+//		return err
+//	}
+//	httpClient.Transport = transport.NewRateLimiter(httpClient.Transport)
+//	client, err := tado.NewClientWithResponses(tado.ServerURL, tado.WithHTTPClient(httpClient))
+package transport
@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/clambin/tado/v2"
+)
+
+var _ http.RoundTripper = &Logger{}
+
+// Logger is an http.RoundTripper that logs all requests with log/slog.
+//
+// For each request, Logger logs the method, the path template (e.g. "/homes/{homeId}/zones/{zoneId}/state") and operation,
+// the status code and the duration. If the logger is enabled for debug level, it also logs the request and response bodies.
+//
+// Logger never logs secrets: Authorization headers, oauth2 tokens and device codes (e.g. in calls to the token endpoint),
+// locations (of mobile devices and homes) and email addresses (e.g. in invitations) are redacted.
+type Logger struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// Logger receives the log records. If nil, slog.Default() is used.
+	Logger *slog.Logger
+}
+
+// NewLogger returns a Logger that sends its requests to next and logs them to logger.
+func NewLogger(next http.RoundTripper, logger *slog.Logger) *Logger {
+	return &Logger{Next: next, Logger: logger}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (l *Logger) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ctx := req.Context()
+	debug := logger.Enabled(ctx, slog.LevelDebug)
+
+	attrs := []slog.Attr{slog.String("method", req.Method)}
+	if op, _, ok := tado.LookupOperation(req); ok {
+		attrs = append(attrs, slog.String("path", op.Path), slog.String("operation", op.ID))
+	} else {
+		attrs = append(attrs, slog.String("path", req.URL.Path))
+	}
+	if debug {
+		attrs = append(attrs, slog.String("url", redactURL(req.URL)), slog.Any("headers", redactHeader(req.Header)))
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			attrs = append(attrs, slog.String("request", string(redactBody(body, req.Header.Get("Content-Type")))))
+		}
+	}
+
+	start := time.Now()
+	resp, err := next(l.Next).RoundTrip(req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "tado api call failed", append(attrs, slog.Any("err", err))...)
+		return nil, err
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if debug {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, slog.String("response", string(redactBody(body, resp.Header.Get("Content-Type")))))
+	}
+	level := slog.LevelInfo
+	if resp.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	logger.LogAttrs(ctx, level, "tado api call", attrs...)
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestLogger(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			_, _ = w.Write([]byte(`{"access_token":"secret-access-token","refresh_token":"secret-refresh-token","expires_in":600}`))
+		case strings.HasSuffix(r.URL.Path, "/mobileDevices"):
+			_, _ = w.Write([]byte(`[{"id":1,"name":"phone","location":{"atHome":true,"relativeDistanceFromHomeFence":0.1}}]`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"accessDenied","title":"access denied"}]}`))
+		}
+	}))
+	defer s.Close()
+
+	tests := []struct {
+		name     string
+		level    slog.Level
+		call     func(t *testing.T, c *http.Client)
+		want     []string
+		wantNone []string
+	}{
+		{
+			name:  "info",
+			level: slog.LevelInfo,
+			call: func(t *testing.T, c *http.Client) {
+				client, _ := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(c))
+				if _, err := client.GetZoneStateWithResponse(t.Context(), 1, 2); err != nil {
+					t.Fatalf("GetZoneState failed: %v", err)
+				}
+			},
+			want:     []string{`level=WARN msg="tado api call" method=GET path=/homes/{homeId}/zones/{zoneId}/state operation=GetZoneState duration=`, "status=403"},
+			wantNone: []string{"accessDenied"},
+		},
+		{
+			name:  "debug",
+			level: slog.LevelDebug,
+			call: func(t *testing.T, c *http.Client) {
+				client, _ := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(c))
+				if _, err := client.GetMobileDevicesWithResponse(t.Context(), 1, func(_ context.Context, req *http.Request) error {
+					req.Header.Set("Authorization", "Bearer secret-access-token")
+					return nil
+				}); err != nil {
+					t.Fatalf("GetMobileDevices failed: %v", err)
+				}
+			},
+			want:     []string{"operation=GetMobileDevices", `\"name\":\"phone\"`, `\"location\":\"REDACTED\"`, "Authorization:[REDACTED]"},
+			wantNone: []string{"secret", "atHome"},
+		},
+		{
+			name:  "debug error",
+			level: slog.LevelDebug,
+			call: func(t *testing.T, c *http.Client) {
+				client, _ := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(c))
+				if _, err := client.GetZoneStateWithResponse(t.Context(), 1, 2); err != nil {
+					t.Fatalf("GetZoneState failed: %v", err)
+				}
+			},
+			want: []string{"status=403", `\"code\":\"accessDenied\"`},
+		},
+		{
+			name:  "token endpoint",
+			level: slog.LevelDebug,
+			call: func(t *testing.T, c *http.Client) {
+				resp, err := c.PostForm(s.URL+"/oauth2/token", url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"secret-refresh-token"}})
+				if err != nil {
+					t.Fatalf("token failed: %v", err)
+				}
+				_ = resp.Body.Close()
+			},
+			want:     []string{"path=/oauth2/token", "grant_type=refresh_token", "refresh_token=REDACTED", `\"access_token\":\"REDACTED\"`, `\"expires_in\":600`},
+			wantNone: []string{"secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: tt.level}))
+			tt.call(t, &http.Client{Transport: NewLogger(nil, logger)})
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("log does not contain %q:\n%s", want, out.String())
+				}
+			}
+			for _, wantNone := range tt.wantNone {
+				if strings.Contains(out.String(), wantNone) {
+					t.Errorf("log contains %q:\n%s", wantNone, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned when the API quota is spent. The returned error is a *QuotaExhaustedError,
+// which reports when the quota resets.
+var ErrQuotaExhausted = errors.New("api quota exhausted")
+
+// QuotaExhaustedError is returned by RateLimiter when the API quota is spent.
+type QuotaExhaustedError struct {
+	// Reset is the time when requests are allowed again. It is zero if the server did not report it.
+	Reset time.Time
+}
+
+func (e *QuotaExhaustedError) Error() string {
+	if e.Reset.IsZero() {
+		return ErrQuotaExhausted.Error()
+	}
+	return fmt.Sprintf("%s: retry after %s", ErrQuotaExhausted, e.Reset.Format(time.RFC3339))
+}
+
+func (e *QuotaExhaustedError) Unwrap() error {
+	return ErrQuotaExhausted
+}
+
+// Quota is the API quota, as reported by the server's rate limit headers.
+type Quota struct {
+	// Reset is the time when the quota resets. It is zero if the server did not report it.
+	Reset time.Time
+	// Limit is the number of requests allowed per quota window. It is zero if the server did not report it.
+	Limit int
+	// Remaining is the number of requests left in the current quota window.
+	Remaining int
+}
+
+var _ http.RoundTripper = &RateLimiter{}
+
+// A RateLimiter is an http.RoundTripper that keeps track of the API quota reported by the server and refuses requests
+// with a *QuotaExhaustedError once the quota is spent, rather than sending requests that the server will reject.
+//
+// RateLimiter supports the IETF RateLimit and RateLimit-Policy headers (as used by Tadoº), as well as the older
+// RateLimit-* and X-RateLimit-* headers. If the server responds with 429 Too Many Requests, RateLimiter honors
+// the Retry-After header: if the delay is at most MaxWait, it waits and retries the request once. Otherwise,
+// it returns a *QuotaExhaustedError.
+type RateLimiter struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// Reserve is the number of requests kept in reserve: once the remaining quota drops to Reserve, requests are refused
+	// until the quota resets. If the server doesn't report when the quota resets, requests are sent regardless.
+	Reserve int
+	// MaxWait is the longest time RateLimiter waits for the server to accept requests again.
+	// If zero, requests are refused immediately.
+	MaxWait    time.Duration
+	quota      Quota
+	retryAfter time.Time
+	hasQuota   bool
+	lock       sync.Mutex
+}
+
+// NewRateLimiter returns a RateLimiter that sends its requests to next.
+func NewRateLimiter(next http.RoundTripper) *RateLimiter {
+	return &RateLimiter{Next: next}
+}
+
+// Quota returns the last API quota reported by the server. If the server has not reported a quota yet, ok is false.
+func (r *RateLimiter) Quota() (quota Quota, ok bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.quota, r.hasQuota
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *RateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retried := false; ; retried = true {
+		if err := r.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := next(r.Next).RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		r.update(resp.Header, now)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now)
+		r.lock.Lock()
+		r.retryAfter = retryAfter
+		r.lock.Unlock()
+		if !ok || retried {
+			return nil, &QuotaExhaustedError{Reset: retryAfter}
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, &QuotaExhaustedError{Reset: retryAfter}
+		}
+	}
+}
+
+// wait blocks until the server accepts requests again, or returns a *QuotaExhaustedError if that takes longer than MaxWait.
+func (r *RateLimiter) wait(ctx context.Context) error {
+	r.lock.Lock()
+	until := r.retryAfter
+	if r.hasQuota && r.quota.Remaining <= r.Reserve && r.quota.Reset.After(until) {
+		until = r.quota.Reset
+	}
+	r.lock.Unlock()
+
+	delay := time.Until(until)
+	if delay <= 0 {
+		return nil
+	}
+	if delay > r.MaxWait {
+		return &QuotaExhaustedError{Reset: until}
+	}
+	return sleep(ctx, delay)
+}
+
+func (r *RateLimiter) update(h http.Header, now time.Time) {
+	quota, ok := parseQuota(h, now)
+	if !ok {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// a response that only reports the remaining requests keeps the previously reported limit
+	if quota.Limit == 0 {
+		quota.Limit = r.quota.Limit
+	}
+	r.quota = quota
+	r.hasQuota = true
+}
+
+// parseQuota parses the rate limit headers of a response.
+func parseQuota(h http.Header, now time.Time) (quota Quota, ok bool) {
+	// IETF draft: RateLimit: "perday";r=100;t=3600 and RateLimit-Policy: "perday";q=20000;w=86400
+	if params := structuredParams(h.Get("RateLimit")); params != nil {
+		if quota.Remaining, ok = atoi(params["r"]); !ok {
+			return Quota{}, false
+		}
+		if reset, ok := atoi(params["t"]); ok {
+			quota.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+		quota.Limit, _ = atoi(structuredParams(h.Get("RateLimit-Policy"))["q"])
+		return quota, true
+	}
+	// older drafts & de-facto standard: (X-)RateLimit-Limit, (X-)RateLimit-Remaining and (X-)RateLimit-Reset
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		if quota.Remaining, ok = atoi(h.Get(prefix + "Remaining")); !ok {
+			continue
+		}
+		quota.Limit, _ = atoi(h.Get(prefix + "Limit"))
+		if reset, ok := atoi(h.Get(prefix + "Reset")); ok {
+			quota.Reset = resetTime(reset, now)
+		}
+		return quota, true
+	}
+	return Quota{}, false
+}
+
+// structuredParams returns the parameters of the first item in a structured header field, e.g. `"perday";r=100;t=3600`.
+// It returns nil if the header is empty.
+func structuredParams(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	item, _, _ := strings.Cut(value, ",")
+	params := make(map[string]string)
+	for _, param := range strings.Split(item, ";")[1:] {
+		if key, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	return params
+}
+
+// resetTime interprets a reset header value, which is either a delay in seconds or a unix timestamp.
+func resetTime(reset int, now time.Time) time.Time {
+	// any value larger than a year can't be a delay
+	if reset > 365*24*60*60 {
+		return time.Unix(int64(reset), 0)
+	}
+	return now.Add(time.Duration(reset) * time.Second)
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a delay in seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if seconds, ok := atoi(value); ok {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func atoi(value string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	return n, err == nil && n >= 0
+}
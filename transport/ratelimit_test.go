@@ -0,0 +1,170 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseQuota(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    Quota
+		wantOK  bool
+	}{
+		{
+			name:   "no headers",
+			wantOK: false,
+		},
+		{
+			name: "ietf",
+			headers: map[string]string{
+				"RateLimit-Policy": `"perday";q=20000;w=86400`,
+				"RateLimit":        `"perday";r=19000;t=3600`,
+			},
+			want:   Quota{Limit: 20000, Remaining: 19000, Reset: now.Add(time.Hour)},
+			wantOK: true,
+		},
+		{
+			name:    "ietf without policy",
+			headers: map[string]string{"RateLimit": `"perday";r=10`},
+			want:    Quota{Remaining: 10},
+			wantOK:  true,
+		},
+		{
+			name:    "invalid ietf",
+			headers: map[string]string{"RateLimit": `"perday";t=10`},
+			wantOK:  false,
+		},
+		{
+			name: "ratelimit",
+			headers: map[string]string{
+				"RateLimit-Limit":     "100",
+				"RateLimit-Remaining": "50",
+				"RateLimit-Reset":     "60",
+			},
+			want:   Quota{Limit: 100, Remaining: 50, Reset: now.Add(time.Minute)},
+			wantOK: true,
+		},
+		{
+			name: "x-ratelimit with timestamp",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1740830400",
+			},
+			want:   Quota{Limit: 100, Remaining: 0, Reset: time.Unix(1740830400, 0)},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := make(http.Header)
+			for key, value := range tt.headers {
+				h.Set(key, value)
+			}
+			got, ok := parseQuota(h, now)
+			if ok != tt.wantOK {
+				t.Fatalf("got ok=%v, want %v", ok, tt.wantOK)
+			}
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining || !got.Reset.Equal(tt.want.Reset) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_QuotaExhausted(t *testing.T) {
+	var remaining atomic.Int32
+	remaining.Store(1)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("RateLimit-Policy", `"perday";q=100;w=86400`)
+		w.Header().Set("RateLimit", `"perday";r=`+strconv.Itoa(int(remaining.Add(-1)))+`;t=3600`)
+	}))
+	defer s.Close()
+
+	r := NewRateLimiter(nil)
+	client := http.Client{Transport: r}
+	if _, ok := r.Quota(); ok {
+		t.Error("quota should not be known yet")
+	}
+
+	resp, err := client.Get(s.URL)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	quota, ok := r.Quota()
+	if !ok || quota.Limit != 100 || quota.Remaining != 0 {
+		t.Fatalf("unexpected quota: %+v", quota)
+	}
+
+	_, err = client.Get(s.URL)
+	if !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("got %v, want ErrQuotaExhausted", err)
+	}
+	var quotaErr *QuotaExhaustedError
+	if !errors.As(err, &quotaErr) || !quotaErr.Reset.Equal(quota.Reset) {
+		t.Errorf("got %v, want reset at %v", err, quota.Reset)
+	}
+	if got := remaining.Load(); got != 0 {
+		t.Errorf("request should not have been sent")
+	}
+}
+
+func TestRateLimiter_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxWait    time.Duration
+		wantErr    bool
+		wantCalls  int32
+		retryAfter string
+	}{
+		{name: "wait", maxWait: 2 * time.Second, retryAfter: "1", wantCalls: 2},
+		{name: "too long", maxWait: time.Second, retryAfter: "3600", wantErr: true, wantCalls: 1},
+		{name: "no retry-after", maxWait: time.Second, wantErr: true, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != "body" {
+					t.Errorf("got body %q", body)
+				}
+				if calls.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			}))
+			defer s.Close()
+
+			r := NewRateLimiter(nil)
+			r.MaxWait = tt.maxWait
+			client := http.Client{Transport: r}
+			resp, err := client.Post(s.URL, "text/plain", strings.NewReader("body"))
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got err %v, want err: %v", err, tt.wantErr)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+			} else if !errors.Is(err, ErrQuotaExhausted) {
+				t.Errorf("got %v, want ErrQuotaExhausted", err)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/clambin/tado/v2"
+)
+
+// An Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// A RecordedRequest is a recorded http.Request. Sensitive values are redacted.
+type RecordedRequest struct {
+	Operation string `json:"operation,omitempty"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Query     string `json:"query,omitempty"`
+	Body      string `json:"body,omitempty"`
+}
+
+// A RecordedResponse is a recorded http.Response. Sensitive values are redacted.
+type RecordedResponse struct {
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	StatusCode int         `json:"status"`
+}
+
+var _ http.RoundTripper = &Recorder{}
+
+// A Recorder is an http.RoundTripper that records all requests and their responses, so they can be replayed
+// with a Replayer. Each Interaction is written to the writer as one line of JSON.
+//
+// Sensitive values (Authorization headers, oauth2 tokens, locations and email addresses) are redacted, so recordings
+// can be shared and committed as test data.
+type Recorder struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next   http.RoundTripper
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+// NewRecorder returns a Recorder that sends its requests to next and writes the interactions to w.
+func NewRecorder(next http.RoundTripper, w io.Writer) *Recorder {
+	return &Recorder{Next: next, writer: w}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recordedRequest, req, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next(r.Next).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	// the body may change length when it's redacted
+	header := redactHeader(resp.Header)
+	header.Del("Content-Length")
+	line, err := json.Marshal(Interaction{
+		Request: recordedRequest,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(redactBody(body, resp.Header.Get("Content-Type"))),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, err = r.writer.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	return resp, nil
+}
+
+// recordRequest returns the RecordedRequest for the request, and a copy of the request whose body can still be read.
+func recordRequest(req *http.Request) (RecordedRequest, *http.Request, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.EscapedPath(),
+	}
+	if op, _, ok := tado.LookupOperation(req); ok {
+		recorded.Operation = op.ID
+	}
+	if req.URL.RawQuery != "" {
+		recorded.Query = redactValues(req.URL.Query()).Encode()
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		recorded.Body = string(redactBody(body, req.Header.Get("Content-Type")))
+	}
+	return recorded, req, nil
+}
+
+// ErrNoInteraction is returned by Replayer when no recorded Interaction matches the request.
+var ErrNoInteraction = errors.New("no recorded interaction for request")
+
+var _ http.RoundTripper = &Replayer{}
+
+// A Replayer is an http.RoundTripper that serves recorded Interactions, without sending any requests.
+//
+// Requests match an Interaction if they have the same method, path, query and body (after redaction).
+// If several Interactions match a request, they are served in the order they were recorded. Once they have all been
+// served, the last one is served for all subsequent requests. If no Interaction matches, Replayer returns ErrNoInteraction.
+type Replayer struct {
+	interactions []Interaction
+	served       []bool
+	lock         sync.Mutex
+}
+
+// NewReplayer returns a Replayer for the Interactions read from r, as written by a Recorder.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	var replayer Replayer
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		replayer.interactions = append(replayer.interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	replayer.served = make([]bool, len(replayer.interactions))
+	return &replayer, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, req, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	last := -1
+	for i, interaction := range r.interactions {
+		if !matches(interaction.Request, recorded) {
+			continue
+		}
+		last = i
+		if !r.served[i] {
+			break
+		}
+	}
+	if last == -1 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.Path)
+	}
+	r.served[last] = true
+	resp := r.interactions[last].Response
+	header := resp.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(resp.Body))),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+func matches(recorded, req RecordedRequest) bool {
+	return recorded.Method == req.Method && recorded.Path == req.Path && recorded.Query == req.Query && recorded.Body == req.Body
+}
@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/clambin/tado/v2"
+)
+
+func TestRecorder_Replayer(t *testing.T) {
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/me"):
+			_, _ = w.Write([]byte(`{"name":"foo","email":"foo@example.com"}`))
+		case strings.HasSuffix(r.URL.Path, "/overlay"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			// zone state changes after every call
+			_, _ = w.Write([]byte(`{"geolocationOverride":` + strconv.FormatBool(calls.Add(1) > 1) + `,"tadoMode":"HOME"}`))
+		}
+	}))
+	defer s.Close()
+
+	// record
+	var recording bytes.Buffer
+	client, err := tado.NewClientWithResponses(s.URL, tado.WithHTTPClient(&http.Client{Transport: NewRecorder(nil, &recording)}))
+	if err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	run := func(client *tado.ClientWithResponses) []string {
+		t.Helper()
+		var results []string
+		me, err := client.GetMeWithResponse(t.Context())
+		if err != nil {
+			t.Fatalf("GetMe failed: %v", err)
+		}
+		results = append(results, string(me.Body))
+		for range 3 {
+			state, err := client.GetZoneStateWithResponse(t.Context(), 1, 2)
+			if err != nil {
+				t.Fatalf("GetZoneState failed: %v", err)
+			}
+			results = append(results, string(state.Body))
+		}
+		overlay, err := client.DeleteZoneOverlayWithResponse(t.Context(), 1, 2)
+		if err != nil {
+			t.Fatalf("DeleteZoneOverlay failed: %v", err)
+		}
+		return append(results, overlay.Status())
+	}
+	recorded := run(client)
+	if got := strings.Count(recording.String(), "\n"); got != 5 {
+		t.Errorf("got %d interactions, want 5", got)
+	}
+	if strings.Contains(recording.String(), "foo@example.com") {
+		t.Errorf("recording contains secrets:\n%s", recording.String())
+	}
+
+	// replay
+	replayer, err := NewReplayer(&recording)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	if client, err = tado.NewClientWithResponses("http://localhost:1", tado.WithHTTPClient(&http.Client{Transport: replayer})); err != nil {
+		t.Fatalf("NewClientWithResponses failed: %v", err)
+	}
+	replayed := run(client)
+	want := append([]string{`{"email":"REDACTED","name":"foo"}`}, recorded[1:]...)
+	if strings.Join(replayed, "\n") != strings.Join(want, "\n") {
+		t.Errorf("replay doesn't match recording:\ngot:  %v\nwant: %v", replayed, want)
+	}
+
+	// unknown request
+	if _, err = client.GetZonesWithResponse(t.Context(), 1); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("got %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestNewReplayer_Invalid(t *testing.T) {
+	if _, err := NewReplayer(strings.NewReader("{}\nnot json\n")); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces sensitive values.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are headers whose values are redacted.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveKeys are JSON fields, form values and query parameters whose values are redacted:
+// oauth2 credentials (e.g. in calls to the token endpoint), bridge auth keys, locations and email addresses.
+var sensitiveKeys = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"id_token":      {},
+	"device_code":   {},
+	"client_secret": {},
+	"password":      {},
+	"authKey":       {},
+	"location":      {},
+	"geolocation":   {},
+	"email":         {},
+}
+
+// sensitiveFormKeys are the sensitiveKeys, plus the oauth2 authorization code. These are only redacted in form values
+// and query parameters: in JSON bodies, "code" identifies the errors returned by the Tadoº API.
+var sensitiveFormKeys = func() map[string]struct{} {
+	keys := maps.Clone(sensitiveKeys)
+	keys["code"] = struct{}{}
+	return keys
+}()
+
+// redactHeader returns a copy of the header with all sensitive values redacted.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range sensitiveHeaders {
+		if h.Get(key) != "" {
+			h.Set(key, redacted)
+		}
+	}
+	return h
+}
+
+// redactURL returns the URL with all sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = redactValues(u.Query()).Encode()
+	return redactedURL.String()
+}
+
+// redactBody returns the body with all sensitive values redacted. JSON and form-encoded bodies are supported.
+// Invalid JSON bodies are replaced completely. Other bodies are returned as is.
+func redactBody(body []byte, contentType string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []byte(redacted)
+		}
+		return []byte(redactValues(values).Encode())
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v any
+	if decoder.Decode(&v) != nil {
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return []byte(redacted)
+		}
+		return body
+	}
+	redactedBody, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return redactedBody
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := sensitiveKeys[key]; ok {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
+
+func redactValues(values url.Values) url.Values {
+	for key := range values {
+		if _, ok := sensitiveFormKeys[key]; ok {
+			values[key] = []string{redacted}
+		}
+	}
+	return values
+}
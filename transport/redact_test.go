@@ -0,0 +1,30 @@
+package transport
+
+import "testing"
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{name: "empty", body: "", want: ""},
+		{name: "json", body: `{"email":"foo@example.com","name":"foo"}`, contentType: "application/json", want: `{"email":"REDACTED","name":"foo"}`},
+		{name: "nested json", body: `[{"invitee":{"email":"foo@example.com"}}]`, contentType: "application/json", want: `[{"invitee":{"email":"REDACTED"}}]`},
+		{name: "home", body: `{"id":1,"geolocation":{"latitude":50.8,"longitude":4.4}}`, want: `{"geolocation":"REDACTED","id":1}`},
+		{name: "form", body: "client_id=foo&device_code=bar", contentType: "application/x-www-form-urlencoded", want: "client_id=foo&device_code=REDACTED"},
+		{name: "authorization code", body: "grant_type=authorization_code&code=bar", contentType: "application/x-www-form-urlencoded", want: "code=REDACTED&grant_type=authorization_code"},
+		{name: "error code", body: `{"errors":[{"code":"accessDenied","title":"access denied"}]}`, contentType: "application/json", want: `{"errors":[{"code":"accessDenied","title":"access denied"}]}`},
+		{name: "invalid json", body: `{"access_token":"secret"`, contentType: "application/json; charset=utf-8", want: "REDACTED"},
+		{name: "invalid problem json", body: `{"access_token":"secret"`, contentType: "application/problem+json", want: "REDACTED"},
+		{name: "text", body: "hello", contentType: "text/plain", want: "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactBody([]byte(tt.body), tt.contentType)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Default settings of Retry.
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+)
+
+var _ http.RoundTripper = &Retry{}
+
+// Retry is an http.RoundTripper that retries idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) that fail
+// with a network error or a 500, 502, 503 or 504 response. Other requests (e.g. POST) are never retried.
+// Requests refused by a RateLimiter (ErrQuotaExhausted) or a CircuitBreaker (ErrCircuitOpen) aren't retried either.
+//
+// Retry waits between attempts with an exponential backoff with jitter. If the request's context has a deadline,
+// Retry doesn't start an attempt that wouldn't start before the deadline.
+type Retry struct {
+	// Next is the http.RoundTripper that performs the request. If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// OnRetry is called before a request is retried. attempt is the number of the failed attempt (starting at 1)
+	// and resp and err contain its result.
+	OnRetry func(req *http.Request, attempt int, resp *http.Response, err error)
+	// MaxAttempts is the maximum number of attempts per request. If zero, DefaultMaxAttempts is used.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It doubles with every attempt. If zero, DefaultInitialBackoff is used.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts. If zero, DefaultMaxBackoff is used.
+	MaxBackoff time.Duration
+}
+
+// NewRetry returns a Retry that sends its requests to next, using the default settings.
+func NewRetry(next http.RoundTripper) *Retry {
+	return &Retry{Next: next}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !idempotent(req.Method) {
+		return next(r.Next).RoundTrip(req)
+	}
+	maxAttempts := r.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := next(r.Next).RoundTrip(req)
+		if attempt >= maxAttempts || !retryable(req.Context(), resp, err) {
+			return resp, err
+		}
+		delay := r.backoff(attempt)
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+		retryReq, rewindErr := rewind(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		if r.OnRetry != nil {
+			r.OnRetry(req, attempt, resp, err)
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err = sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		req = retryReq
+	}
+}
+
+// backoff returns the delay before the next attempt: half of the exponential backoff, plus a random jitter of up to the other half.
+func (r *Retry) backoff(attempt int) time.Duration {
+	initial, maxBackoff := r.InitialBackoff, r.MaxBackoff
+	if initial <= 0 {
+		initial = DefaultInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	delay := maxBackoff
+	if attempt < 32 {
+		delay = min(initial<<(attempt-1), maxBackoff)
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, ErrQuotaExhausted) && !errors.Is(err, ErrCircuitOpen)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		failures   int32
+		reset      bool
+		wantCalls  int32
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "success", method: http.MethodGet, wantCalls: 1, wantStatus: http.StatusOK},
+		{name: "get recovers", method: http.MethodGet, failures: 2, wantCalls: 3, wantStatus: http.StatusOK},
+		{name: "get fails", method: http.MethodGet, failures: 3, wantCalls: 3, wantStatus: http.StatusServiceUnavailable},
+		{name: "put recovers", method: http.MethodPut, failures: 1, wantCalls: 2, wantStatus: http.StatusOK},
+		{name: "delete recovers", method: http.MethodDelete, failures: 1, wantCalls: 2, wantStatus: http.StatusOK},
+		{name: "post not retried", method: http.MethodPost, failures: 1, wantCalls: 1, wantStatus: http.StatusServiceUnavailable},
+		{name: "connection reset", method: http.MethodGet, failures: 1, reset: true, wantCalls: 2, wantStatus: http.StatusOK},
+		{name: "post connection reset", method: http.MethodPost, failures: 1, reset: true, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != "body" {
+					t.Errorf("got body %q", body)
+				}
+				if calls.Add(1) > tt.failures {
+					return
+				}
+				if tt.reset {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					_ = conn.Close()
+					return
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer s.Close()
+
+			var retries int
+			r := NewRetry(nil)
+			r.InitialBackoff = time.Millisecond
+			r.OnRetry = func(_ *http.Request, _ int, _ *http.Response, _ error) { retries++ }
+			req, _ := http.NewRequestWithContext(t.Context(), tt.method, s.URL, strings.NewReader("body"))
+			resp, err := (&http.Client{Transport: r}).Do(req)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got err %v, want err: %v", err, tt.wantErr)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("got status %d, want %d", resp.StatusCode, tt.wantStatus)
+				}
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", got, tt.wantCalls)
+			}
+			if want := int(tt.wantCalls) - 1; retries != want {
+				t.Errorf("got %d retries, want %d", retries, want)
+			}
+		})
+	}
+}
+
+func TestRetry_Deadline(t *testing.T) {
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	r := Retry{InitialBackoff: time.Second, MaxAttempts: 10}
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	resp, err := (&http.Client{Transport: &r}).Do(req)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusBadGateway)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("got %d calls, want 1", got)
+	}
+}
+
+func TestRetry_CircuitOpen(t *testing.T) {
+	var calls int
+	r := Retry{
+		Next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return nil, &CircuitOpenError{Retry: time.Now().Add(time.Minute)}
+		}),
+		InitialBackoff: time.Millisecond,
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if _, err := r.RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}